@@ -0,0 +1,224 @@
+package loggy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewLevelHandler returns an http.Handler for inspecting and changing the levels of the
+// given Loggers at runtime. It can be mounted on any path of an existing mux:
+//
+//	mux.Handle("/admin/log-levels", NewLevelHandler(appLogger, dbLogger))
+//
+// GET lists every Logger with its current level, or a single one with ?name=db:
+//
+//	[{"name": "app", "level": "info"}, {"name": "db", "level": "warn"}]
+//
+// PUT (or POST) changes levels. The request is either JSON or a form with the same keys:
+//
+//	{"name": "db", "level": "debug", "ttl": "10m"}
+//
+// An empty name applies the change to every Logger. With a ttl (a Go duration), the
+// previous level is restored automatically once it elapses; changing the level again
+// before then replaces the timer but still restores the level from before the first
+// temporary change. The response lists the affected Loggers.
+//
+// Without arguments, the handler serves every Logger in the global registry (see
+// Loggers), including those registered after it was created.
+func NewLevelHandler(loggers ...*Logger) *LevelHandler {
+	h := &LevelHandler{
+		loggers:   make(map[string]*Logger),
+		overrides: make(map[string]*levelOverride),
+	}
+	for _, l := range loggers {
+		h.Register(l)
+	}
+	h.useRegistry = len(loggers) == 0
+	return h
+}
+
+// Register adds a Logger to the handler under its Name, replacing any Logger of the same name.
+func (h *LevelHandler) Register(l *Logger) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.loggers[l.Name()] = l
+}
+
+// ServeHTTP implements http.Handler.
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		states, err := h.states(r.URL.Query().Get("name"))
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, states)
+	case http.MethodPut, http.MethodPost:
+		h.serveUpdate(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+// serveUpdate decodes and applies a level change request.
+func (h *LevelHandler) serveUpdate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name  string `json:"name"`
+		Level string `json:"level"`
+		TTL   string `json:"ttl"`
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+			return
+		}
+	} else {
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<16)
+		if err := r.ParseForm(); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+		req.Name, req.Level, req.TTL = r.Form.Get("name"), r.Form.Get("level"), r.Form.Get("ttl")
+	}
+	level, err := ParseSeverity(req.Level)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid ttl %q", req.TTL))
+			return
+		}
+	}
+	names, err := h.SetLevel(req.Name, level, ttl)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+	states := make([]levelState, 0, len(names))
+	for _, name := range names {
+		s, _ := h.states(name)
+		states = append(states, s...)
+	}
+	writeJSON(w, http.StatusOK, states)
+}
+
+// SetLevel changes the level of the named Logger, or of every Logger if name is empty, and
+// returns the names of the affected Loggers. If ttl is positive, the previous level is
+// restored once it elapses.
+func (h *LevelHandler) SetLevel(name string, level Severity, ttl time.Duration) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	loggers, err := h.loggersLocked(name)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(loggers))
+	for _, l := range loggers {
+		h.setLevelLocked(l, level, ttl)
+		names = append(names, l.Name())
+	}
+	return names, nil
+}
+
+// setLevelLocked applies a level change to a single Logger. h.mu must be held.
+func (h *LevelHandler) setLevelLocked(l *Logger, level Severity, ttl time.Duration) {
+	name := l.Name()
+	previous, inherited := l.GetLevel(), l.inheritsLevel()
+	if o, ok := h.overrides[name]; ok {
+		o.timer.Stop()
+		previous, inherited = o.previous, o.inherited
+		delete(h.overrides, name)
+	}
+	l.SetLevel(level)
+	if ttl <= 0 {
+		return
+	}
+	o := &levelOverride{previous: previous, inherited: inherited, expires: time.Now().Add(ttl)}
+	o.timer = time.AfterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.overrides[name] == o {
+			if o.inherited {
+				l.InheritLevel()
+			} else {
+				l.SetLevel(o.previous)
+			}
+			delete(h.overrides, name)
+		}
+	})
+	h.overrides[name] = o
+}
+
+// states returns the level of the named Logger, or of every Logger sorted by name.
+func (h *LevelHandler) states(name string) ([]levelState, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	loggers, err := h.loggersLocked(name)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]levelState, 0, len(loggers))
+	for _, l := range loggers {
+		s := levelState{Name: l.Name(), Level: l.GetLevel().String()}
+		if o, ok := h.overrides[l.Name()]; ok {
+			expires := o.expires
+			s.Expires = &expires
+		}
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+// loggersLocked resolves name to the Logger it designates, or to every Logger sorted by
+// name if name is empty. Loggers given to the handler take precedence over those of the
+// global registry, which is looked up on every call so that replaced Loggers are not
+// served. h.mu must be held.
+func (h *LevelHandler) loggersLocked(name string) ([]*Logger, error) {
+	if name != "" {
+		if l, ok := h.loggers[name]; ok {
+			return []*Logger{l}, nil
+		}
+		if h.useRegistry {
+			if l, ok := Lookup(name); ok {
+				return []*Logger{l}, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown logger %q", name)
+	}
+	byName := make(map[string]*Logger, len(h.loggers))
+	if h.useRegistry {
+		for _, l := range Loggers() {
+			byName[l.Name()] = l
+		}
+	}
+	for n, l := range h.loggers {
+		byName[n] = l
+	}
+	loggers := make([]*Logger, 0, len(byName))
+	for _, l := range byName {
+		loggers = append(loggers, l)
+	}
+	sort.Slice(loggers, func(i, j int) bool { return loggers[i].Name() < loggers[j].Name() })
+	return loggers, nil
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError writes err as a JSON error response with the given status code.
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
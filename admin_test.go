@@ -0,0 +1,133 @@
+package loggy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decodeStates decodes a LevelHandler response body.
+func decodeStates(t *testing.T, body io.Reader) []levelState {
+	t.Helper()
+	var states []levelState
+	if err := json.NewDecoder(body).Decode(&states); err != nil {
+		t.Fatalf("Unexpected response body: %v", err)
+	}
+	return states
+}
+
+// TestLevelHandlerList verifies that GET lists loggers sorted by name.
+func TestLevelHandlerList(t *testing.T) {
+	h := NewLevelHandler(New(": svc:", io.Discard, InfoIssuer), New(": db:", io.Discard, WarnIssuer))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	states := decodeStates(t, rec.Body)
+	if len(states) != 2 || states[0] != (levelState{Name: "db", Level: "warn"}) || states[1] != (levelState{Name: "svc", Level: "info"}) {
+		t.Errorf("Unexpected states: %+v", states)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown logger, got %d", rec.Code)
+	}
+}
+
+// TestLevelHandlerUpdate verifies JSON and form updates.
+func TestLevelHandlerUpdate(t *testing.T) {
+	svc := New(": svc:", io.Discard, InfoIssuer)
+	db := New(": db:", io.Discard, InfoIssuer)
+	h := NewLevelHandler(svc, db)
+
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name": "db", "level": "debug"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || db.GetLevel() != DebugIssuer || svc.GetLevel() != InfoIssuer {
+		t.Fatalf("Expected only db to change, got status %d, db %v, svc %v", rec.Code, db.GetLevel(), svc.GetLevel())
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(url.Values{"level": {"error"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || db.GetLevel() != ErrorIssuer || svc.GetLevel() != ErrorIssuer {
+		t.Fatalf("Expected all loggers to change, got status %d, db %v, svc %v", rec.Code, db.GetLevel(), svc.GetLevel())
+	}
+	if states := decodeStates(t, rec.Body); len(states) != 2 {
+		t.Errorf("Expected both loggers in the response, got %+v", states)
+	}
+
+	for _, body := range []string{`{"level": "loud"}`, `{"level": "debug", "ttl": "soon"}`, `{`} {
+		req = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, rec.Code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for DELETE, got %d", rec.Code)
+	}
+}
+
+// TestLevelHandlerTTL verifies that temporary levels are reverted to the original level.
+func TestLevelHandlerTTL(t *testing.T) {
+	svc := New(": svc:", io.Discard, InfoIssuer)
+	h := NewLevelHandler(svc)
+	if _, err := h.SetLevel("svc", WarnIssuer, time.Hour); err != nil {
+		t.Fatalf("Unexpected error from SetLevel: %v", err)
+	}
+	// A second temporary change still reverts to the level before the first one.
+	if _, err := h.SetLevel("svc", DebugIssuer, 20*time.Millisecond); err != nil {
+		t.Fatalf("Unexpected error from SetLevel: %v", err)
+	}
+	states, _ := h.states("svc")
+	if states[0].Level != "debug" || states[0].Expires == nil {
+		t.Errorf("Expected a temporary debug level, got %+v", states[0])
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for svc.GetLevel() != InfoIssuer {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected level to revert to info, got %v", svc.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if states, _ := h.states("svc"); states[0].Expires != nil {
+		t.Errorf("Expected no expiry after reverting, got %+v", states[0])
+	}
+}
+
+// TestLevelHandlerTTLInherited verifies that a sub-logger inherits its parent's level again
+// once a temporary level expires.
+func TestLevelHandlerTTLInherited(t *testing.T) {
+	svc := New(": svc:", io.Discard, InfoIssuer)
+	db := svc.Named("db")
+	h := NewLevelHandler(db)
+	if _, err := h.SetLevel("svc.db", DebugIssuer, 10*time.Millisecond); err != nil {
+		t.Fatalf("Unexpected error from SetLevel: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !db.inheritsLevel() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected db to inherit its level again, got %v", db.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	svc.SetLevel(ErrorIssuer)
+	if db.GetLevel() != ErrorIssuer {
+		t.Errorf("Expected db to follow svc, got %v", db.GetLevel())
+	}
+}
@@ -0,0 +1,81 @@
+package loggy
+
+import (
+	"io"
+	"testing"
+)
+
+// BenchmarkFilteredDebug measures a call filtered out by the level threshold.
+func BenchmarkFilteredDebug(b *testing.B) {
+	logger := New(": bench:", io.Discard, InfoIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Debug("filtered message")
+	}
+}
+
+// BenchmarkFilteredDebugf measures a formatted call filtered out by the level threshold.
+func BenchmarkFilteredDebugf(b *testing.B) {
+	logger := New(": bench:", io.Discard, InfoIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Debugf("filtered %s", "message")
+	}
+}
+
+// BenchmarkInfo measures an emitted call with caller lookup.
+func BenchmarkInfo(b *testing.B) {
+	logger := New(": bench:", io.Discard, DebugIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Info("emitted message")
+	}
+}
+
+// BenchmarkInfoNoCaller measures an emitted call with the caller lookup disabled.
+func BenchmarkInfoNoCaller(b *testing.B) {
+	logger := New(": bench:", io.Discard, DebugIssuer, WithCallerStyle(CallerNone))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Info("emitted message")
+	}
+}
+
+// BenchmarkInfof measures an emitted formatted call.
+func BenchmarkInfof(b *testing.B) {
+	logger := New(": bench:", io.Discard, DebugIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Infof("emitted %s %d", "message", 42)
+	}
+}
+
+// BenchmarkInfoFields measures an emitted call carrying structured fields.
+func BenchmarkInfoFields(b *testing.B) {
+	logger := New(": bench:", io.Discard, DebugIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.Info("emitted message", F("status", 200), F("path", "/healthz"))
+	}
+}
+
+// TestFilteredCallsDoNotAllocate guards the zero-allocation guarantee for filtered calls.
+func TestFilteredCallsDoNotAllocate(t *testing.T) {
+	logger := New(": bench:", io.Discard, InfoIssuer)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = logger.Debug("filtered message")
+		_ = logger.Debugf("filtered %s", "message")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected filtered calls not to allocate, got %.1f allocations", allocs)
+	}
+}
+
+// BenchmarkFilteredDebugFn measures a lazily evaluated call filtered out by the level threshold.
+func BenchmarkFilteredDebugFn(b *testing.B) {
+	logger := New(": bench:", io.Discard, InfoIssuer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = logger.DebugFn(func() string { return "filtered message" })
+	}
+}
@@ -0,0 +1,75 @@
+package loggy
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxPooledBufferSize is the largest buffer capacity returned to the pool. Larger buffers,
+// produced by unusually long entries, are left to the garbage collector so that a single
+// large entry does not pin memory for the lifetime of the process.
+const maxPooledBufferSize = 64 << 10
+
+// buffer is a growable byte slice used to encode a single entry.
+type buffer struct {
+	b []byte
+}
+
+// bufferPool recycles encoding buffers between log calls.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &buffer{b: make([]byte, 0, 256)}
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *buffer {
+	buf := bufferPool.Get().(*buffer)
+	buf.b = buf.b[:0]
+	return buf
+}
+
+// free returns the buffer to the pool unless it has grown too large.
+func (buf *buffer) free() {
+	if cap(buf.b) <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
+
+// WriteString appends s to the buffer.
+func (buf *buffer) WriteString(s string) {
+	buf.b = append(buf.b, s...)
+}
+
+// WriteByte appends c to the buffer. The error is always nil; it is only returned
+// to satisfy io.ByteWriter.
+func (buf *buffer) WriteByte(c byte) error {
+	buf.b = append(buf.b, c)
+	return nil
+}
+
+// WriteInt appends the decimal representation of i to the buffer.
+func (buf *buffer) WriteInt(i int64) {
+	buf.b = strconv.AppendInt(buf.b, i, 10)
+}
+
+// WriteTime appends t formatted according to layout to the buffer.
+func (buf *buffer) WriteTime(t time.Time, layout string) {
+	buf.b = t.AppendFormat(buf.b, layout)
+}
+
+// WriteQuoted appends s as a double-quoted Go string literal to the buffer.
+func (buf *buffer) WriteQuoted(s string) {
+	buf.b = strconv.AppendQuote(buf.b, s)
+}
+
+// Len returns the number of bytes in the buffer.
+func (buf *buffer) Len() int {
+	return len(buf.b)
+}
+
+// String returns the buffer contents as a string.
+func (buf *buffer) String() string {
+	return string(buf.b)
+}
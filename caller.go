@@ -0,0 +1,138 @@
+package loggy
+
+import (
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// mainModule returns the import path of the main module, or an empty string if the
+// binary was built without module support.
+var mainModule = sync.OnceValue(func() string {
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		return bi.Main.Path
+	}
+	return ""
+})
+
+// WithCallerStyle returns an Option that sets how the caller location is rendered.
+// Use CallerNone to disable the caller lookup entirely for performance-sensitive paths.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithCallerStyle(CallerModule))
+func WithCallerStyle(style CallerStyle) Option {
+	return func(l *Logger) {
+		if style <= CallerNone {
+			l.callerStyle = style
+			l.overrides |= setCallerStyle
+		}
+	}
+}
+
+// WithCallerFunction returns an Option that appends the caller's function name
+// (e.g. "api.(*Server).Handle") to the rendered location when set to true.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithCallerFunction(true))
+func WithCallerFunction(enabled bool) Option {
+	return func(l *Logger) {
+		l.callerFunc = enabled
+		l.overrides |= setCallerFunc
+	}
+}
+
+// captureCaller returns the stack frame skip levels above its own caller,
+// using the same numbering as runtime.Caller. Unlike runtime.Caller and
+// runtime.CallersFrames, it resolves the frame without allocating.
+func captureCaller(skip int) (Frame, bool) {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return Frame{}, false
+	}
+	// The returned value is a return address; step back into the call instruction
+	// so that the file, line and (possibly inlined) function are those of the call.
+	pc := pcs[0] - 1
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return Frame{}, false
+	}
+	file, line := fn.FileLine(pc)
+	return Frame{Function: fn.Name(), File: file, Line: line}, true
+}
+
+// Package returns the import path of the package containing the frame's function,
+// or an empty string if the function name is unknown.
+func (f Frame) Package() string {
+	name := f.Function
+	slash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return ""
+}
+
+// ShortFunction returns the function name qualified by the last element of its
+// package path (e.g. "api.(*Server).Handle").
+func (f Frame) ShortFunction() string {
+	return f.Function[strings.LastIndexByte(f.Function, '/')+1:]
+}
+
+// Location renders the frame as "file:line" using the given CallerStyle.
+// CallerNone yields an empty string.
+func (f Frame) Location(style CallerStyle) string {
+	var b buffer
+	writeLocation(&b, f, style)
+	return b.String()
+}
+
+// writeLocation writes the frame's "file:line" representation to b according to style.
+func writeLocation(b *buffer, f Frame, style CallerStyle) {
+	switch style {
+	case CallerNone:
+		return
+	case CallerFull:
+		b.WriteString(f.File)
+	case CallerModule:
+		b.WriteString(moduleRelative(f))
+	case CallerPackage:
+		if pkg := f.Package(); pkg != "" {
+			b.WriteString(path.Base(pkg))
+			b.WriteByte('/')
+		}
+		b.WriteString(filepath.Base(f.File))
+	default:
+		b.WriteString(filepath.Base(f.File))
+	}
+	b.WriteByte(':')
+	b.WriteInt(int64(f.Line))
+}
+
+// moduleRelative returns the frame's file path relative to the main module root.
+// The package import path is used to locate the file so that the result does not
+// depend on where the module was checked out or whether -trimpath was used.
+// Files in other modules are rendered with their full package path.
+func moduleRelative(f Frame) string {
+	base := filepath.Base(f.File)
+	pkg := f.Package()
+	if pkg == "" {
+		return base
+	}
+	if mod := mainModule(); mod != "" {
+		if pkg == mod {
+			return base
+		}
+		if rel, ok := strings.CutPrefix(pkg, mod+"/"); ok {
+			return rel + "/" + base
+		}
+	}
+	// The main package of a binary reports "main" rather than its import path.
+	if pkg == "main" {
+		return base
+	}
+	return pkg + "/" + base
+}
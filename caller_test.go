@@ -0,0 +1,102 @@
+package loggy
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCallerStyles verifies the rendering of each CallerStyle.
+func TestCallerStyles(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	tests := []struct {
+		style CallerStyle
+		want  string
+	}{
+		{CallerBase, " caller_test.go:"},
+		{CallerModule, " caller_test.go:"},
+		{CallerPackage, " loggy/caller_test.go:"},
+		{CallerFull, " " + file + ":"},
+	}
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		logger := New(": test-service:", buf, DebugIssuer, WithCallerStyle(tt.style))
+		if err := logger.Info("styled"); err != nil {
+			t.Errorf("Unexpected error from Info: %v", err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("Style %d: expected output to contain %q, got: %s", tt.style, tt.want, buf.String())
+		}
+	}
+}
+
+// TestCallerNone verifies that the caller location is omitted when disabled.
+func TestCallerNone(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerStyle(CallerNone))
+	if err := logger.Info("no caller"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if strings.Contains(buf.String(), ".go:") {
+		t.Errorf("Expected no caller location, got: %s", buf.String())
+	}
+	if !strings.HasSuffix(buf.String(), "info: no caller\n") {
+		t.Errorf("Expected message to follow the severity label, got: %q", buf.String())
+	}
+}
+
+// TestCallerFunction verifies that the function name is appended when enabled.
+func TestCallerFunction(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerFunction(true))
+	if err := logger.Info("with function"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if !strings.Contains(buf.String(), " loggy.TestCallerFunction: with function") {
+		t.Errorf("Expected function name in output, got: %s", buf.String())
+	}
+}
+
+// TestModuleRelative verifies path resolution against the main module for frames inside
+// and outside of it.
+func TestModuleRelative(t *testing.T) {
+	mod := mainModule()
+	if mod == "" {
+		t.Skip("build info not available")
+	}
+	tests := []struct {
+		frame Frame
+		want  string
+	}{
+		{Frame{Function: mod + ".New", File: "/src/loggy.go"}, "loggy.go"},
+		{Frame{Function: mod + "/internal/api.(*Server).Handle", File: "/src/internal/api/handler.go"}, "internal/api/handler.go"},
+		{Frame{Function: "net/http.HandlerFunc.ServeHTTP", File: "/go/src/net/http/server.go"}, "net/http/server.go"},
+		{Frame{Function: "main.main", File: "/src/cmd/main.go"}, "main.go"},
+	}
+	for _, tt := range tests {
+		if got := moduleRelative(tt.frame); got != tt.want {
+			t.Errorf("moduleRelative(%q) = %q, want %q", tt.frame.Function, got, tt.want)
+		}
+	}
+}
+
+// infoThroughWrapper is small enough to be inlined, exercising caller resolution of inlined frames.
+func infoThroughWrapper(l *Logger, msg string) error {
+	return l.Info(Caller(1), msg)
+}
+
+// TestCallerInlinedWrapper verifies that a Caller skip through an inlined wrapper reports the right line.
+func TestCallerInlinedWrapper(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerFunction(true))
+	_, _, line, _ := runtime.Caller(0)
+	if err := infoThroughWrapper(logger, "wrapped"); err != nil {
+		t.Errorf("Unexpected error from wrapper: %v", err)
+	}
+	want := " caller_test.go:" + strconv.Itoa(line+1) + " loggy.TestCallerInlinedWrapper: wrapped"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected output to contain %q, got: %s", want, buf.String())
+	}
+}
@@ -0,0 +1,105 @@
+package loggy
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// WithColor returns an Option that controls ANSI colouring of the severity label and
+// caller location. With ColorAuto the decision is made from the writer: colour is only
+// used when it is a terminal, unless overridden by the NO_COLOR or FORCE_COLOR
+// environment variables. The decision is re-evaluated whenever UpdateWriter is called.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithColor(ColorAuto))
+func WithColor(mode ColorMode) Option {
+	return func(l *Logger) {
+		if mode <= ColorAlways {
+			l.colorMode = mode
+			l.overrides |= setColorMode
+		}
+	}
+}
+
+// WithColorTheme returns an Option that sets the ANSI SGR parameters used to colour each
+// severity label. The theme only takes effect when colour is enabled through WithColor.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer,
+//		WithColor(ColorAuto),
+//		WithColorTheme(ColorTheme{WarnIssuer: "1;35", ErrorIssuer: "1;31"}))
+func WithColorTheme(theme ColorTheme) Option {
+	return func(l *Logger) {
+		if theme != nil {
+			l.colorTheme = theme
+			l.overrides |= setColorTheme
+		}
+	}
+}
+
+// shouldColorize reports whether output written to w should be colourised under the given mode.
+func shouldColorize(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		// Like NO_COLOR, FORCE_COLOR only applies when set to a non-empty value.
+		if v := strings.ToLower(strings.TrimSpace(os.Getenv("FORCE_COLOR"))); v != "" {
+			return v != "0" && v != "false"
+		}
+		if v := os.Getenv("NO_COLOR"); v != "" {
+			return false
+		}
+		return isTerminal(w)
+	default:
+		return false
+	}
+}
+
+// isTerminal reports whether w is an *os.File connected to a character device such as a TTY.
+// The target of a writer installed by WatchConfig is checked instead of the writer itself.
+func isTerminal(w io.Writer) bool {
+	if sw, ok := w.(*switchWriter); ok {
+		sw.mu.RLock()
+		w = sw.w
+		sw.mu.RUnlock()
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// writeColored writes s to b wrapped in the ANSI escape sequence for the SGR parameters in code.
+// If code is empty, s is written as is.
+func writeColored(b *buffer, code, s string) {
+	beginColor(b, code)
+	b.WriteString(s)
+	endColor(b, code)
+}
+
+// beginColor writes the ANSI escape sequence that starts the SGR parameters in code.
+func beginColor(b *buffer, code string) {
+	if code == "" {
+		return
+	}
+	b.WriteString("\x1b[")
+	b.WriteString(code)
+	b.WriteByte('m')
+}
+
+// endColor writes the ANSI reset sequence if a colour was started for code.
+func endColor(b *buffer, code string) {
+	if code == "" {
+		return
+	}
+	b.WriteString("\x1b[0m")
+}
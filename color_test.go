@@ -0,0 +1,103 @@
+package loggy
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestColorNeverByDefault verifies that output is not colourised unless requested.
+func TestColorNeverByDefault(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	if err := logger.Error("plain"); err != nil {
+		t.Errorf("Unexpected error from Error: %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("Expected no ANSI escape sequences, got: %q", buf.String())
+	}
+}
+
+// TestColorAlways verifies that the severity label and caller are wrapped in ANSI sequences.
+func TestColorAlways(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithColor(ColorAlways))
+	if err := logger.Warn("coloured"); err != nil {
+		t.Errorf("Unexpected error from Warn: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "\x1b[33mwarn:\x1b[0m") {
+		t.Errorf("Expected coloured severity label, got: %q", output)
+	}
+	if !strings.Contains(output, "\x1b[2mcolor_test.go:") {
+		t.Errorf("Expected coloured caller location, got: %q", output)
+	}
+}
+
+// TestColorTheme verifies that a custom theme overrides the default colours.
+func TestColorTheme(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer,
+		WithColor(ColorAlways),
+		WithColorTheme(ColorTheme{InfoIssuer: "1;35"}))
+	if err := logger.Info("themed"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[1;35minfo:\x1b[0m") {
+		t.Errorf("Expected themed severity label, got: %q", buf.String())
+	}
+	buf.Reset()
+	if err := logger.Debug("unthemed"); err != nil {
+		t.Errorf("Unexpected error from Debug: %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[36m") || !strings.Contains(buf.String(), "debug:") {
+		t.Errorf("Expected debug label without colour, got: %q", buf.String())
+	}
+}
+
+// TestColorAutoEnvironment verifies the NO_COLOR and FORCE_COLOR handling for ColorAuto.
+func TestColorAutoEnvironment(t *testing.T) {
+	buf := new(bytes.Buffer)
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	if !shouldColorize(ColorAuto, buf) {
+		t.Error("Expected FORCE_COLOR to enable colour for a non-terminal writer")
+	}
+	t.Setenv("FORCE_COLOR", "0")
+	if shouldColorize(ColorAuto, buf) {
+		t.Error("Expected FORCE_COLOR=0 to disable colour")
+	}
+	t.Setenv("FORCE_COLOR", "")
+	if shouldColorize(ColorAuto, buf) {
+		t.Error("Expected an empty FORCE_COLOR to be ignored")
+	}
+	os.Unsetenv("FORCE_COLOR")
+	t.Setenv("NO_COLOR", "1")
+	if shouldColorize(ColorAuto, buf) {
+		t.Error("Expected NO_COLOR to disable colour")
+	}
+	if !shouldColorize(ColorAlways, buf) {
+		t.Error("Expected ColorAlways to ignore NO_COLOR")
+	}
+	os.Unsetenv("NO_COLOR")
+	if shouldColorize(ColorAuto, buf) {
+		t.Error("Expected ColorAuto to disable colour for a non-terminal writer")
+	}
+}
+
+// TestColorUpdateWriter verifies that the colour decision follows the writer.
+func TestColorUpdateWriter(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	os.Unsetenv("FORCE_COLOR")
+	logger := New(": test-service:", new(bytes.Buffer), DebugIssuer, WithColor(ColorAuto))
+	if logger.current.Load().colorize {
+		t.Fatal("Expected no colour for a buffer writer")
+	}
+	t.Setenv("FORCE_COLOR", "1")
+	logger.UpdateWriter(new(bytes.Buffer))
+	if !logger.current.Load().colorize {
+		t.Error("Expected colour decision to be re-evaluated on UpdateWriter")
+	}
+}
@@ -0,0 +1,307 @@
+package loggy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// callerStyleNames maps the names accepted in a Config to caller styles.
+var callerStyleNames = map[string]CallerStyle{
+	"base":    CallerBase,
+	"module":  CallerModule,
+	"package": CallerPackage,
+	"full":    CallerFull,
+	"none":    CallerNone,
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	return "loggy: config: " + e.Field + ": " + e.Reason
+}
+
+// ParseConfig decodes a JSON configuration. Unknown keys are rejected so that typos
+// do not silently fall back to defaults. The result is not validated; call Validate
+// or Build for that.
+//
+// Example:
+//
+//	cfg, err := ParseConfig([]byte(`{"name": "my-service", "level": "info", "outputs": ["stdout", "/var/log/app.log"]}`))
+func ParseConfig(data []byte) (Config, error) {
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("loggy: config: %w", err)
+	}
+	return c, nil
+}
+
+// LoadConfig reads and decodes the JSON configuration file at path, then applies any
+// LOGGY_* environment variable overrides (see ApplyEnv).
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	c, err := ParseConfig(data)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := c.ApplyEnv(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// ConfigFromEnv returns a Config populated only from LOGGY_* environment variables.
+func ConfigFromEnv() (Config, error) {
+	var c Config
+	err := c.ApplyEnv()
+	return c, err
+}
+
+// ApplyEnv overrides settings with the following environment variables, when set:
+//
+//	LOGGY_NAME, LOGGY_LEVEL, LOGGY_TIME_FORMAT, LOGGY_UTC, LOGGY_SEVERITY_NAMES,
+//	LOGGY_OUTPUTS, LOGGY_ENCODER, LOGGY_CALLER, LOGGY_ROTATION_MAX_SIZE_MB,
+//	LOGGY_ROTATION_MAX_BACKUPS
+//
+// List values (severity names, outputs) are comma-separated. All malformed values are
+// reported together; well-formed ones are applied regardless.
+func (c *Config) ApplyEnv() error {
+	var errs []error
+	if v, ok := os.LookupEnv("LOGGY_NAME"); ok {
+		c.Name = v
+	}
+	if v, ok := os.LookupEnv("LOGGY_LEVEL"); ok {
+		c.Level = v
+	}
+	if v, ok := os.LookupEnv("LOGGY_TIME_FORMAT"); ok {
+		c.TimeFormat = v
+	}
+	if v, ok := os.LookupEnv("LOGGY_UTC"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, &ConfigError{Field: "LOGGY_UTC", Reason: fmt.Sprintf("invalid boolean %q", v)})
+		} else {
+			c.UTC = b
+		}
+	}
+	if v, ok := os.LookupEnv("LOGGY_SEVERITY_NAMES"); ok {
+		c.SeverityNames = splitList(v)
+	}
+	if v, ok := os.LookupEnv("LOGGY_OUTPUTS"); ok {
+		c.Outputs = splitList(v)
+	}
+	if v, ok := os.LookupEnv("LOGGY_ENCODER"); ok {
+		c.Encoder = v
+	}
+	if v, ok := os.LookupEnv("LOGGY_CALLER"); ok {
+		c.Caller = v
+	}
+	for _, env := range []struct {
+		name string
+		dst  *int
+	}{
+		{"LOGGY_ROTATION_MAX_SIZE_MB", &c.Rotation.MaxSizeMB},
+		{"LOGGY_ROTATION_MAX_BACKUPS", &c.Rotation.MaxBackups},
+	} {
+		if v, ok := os.LookupEnv(env.name); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, &ConfigError{Field: env.name, Reason: fmt.Sprintf("invalid integer %q", v)})
+				continue
+			}
+			*env.dst = n
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks every setting and returns all problems at once, joined with errors.Join.
+// Each problem is a *ConfigError. It returns nil if the configuration is valid.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Name != "" {
+		if problem := nameProblem(c.Name); problem != "" {
+			errs = append(errs, &ConfigError{Field: "name", Reason: fmt.Sprintf("%q %s", c.Name, problem)})
+		}
+	}
+	if c.Level != "" {
+		if _, err := ParseSeverity(c.Level); err != nil {
+			errs = append(errs, &ConfigError{Field: "level", Reason: fmt.Sprintf("unknown severity %q", c.Level)})
+		}
+	}
+	if c.SeverityNames != nil && len(c.SeverityNames) != 5 {
+		errs = append(errs, &ConfigError{Field: "severity_names", Reason: fmt.Sprintf("expected 5 labels, got %d", len(c.SeverityNames))})
+	}
+	for i, out := range c.Outputs {
+		if strings.TrimSpace(out) == "" {
+			errs = append(errs, &ConfigError{Field: "outputs", Reason: fmt.Sprintf("entry %d is empty", i)})
+		}
+	}
+	switch strings.ToLower(c.Encoder) {
+	case "", "text", "console":
+	default:
+		errs = append(errs, &ConfigError{Field: "encoder", Reason: fmt.Sprintf("unknown encoder %q (want \"text\" or \"console\")", c.Encoder)})
+	}
+	if _, ok := callerStyleNames[strings.ToLower(c.Caller)]; !ok && c.Caller != "" {
+		errs = append(errs, &ConfigError{Field: "caller", Reason: fmt.Sprintf("unknown caller style %q", c.Caller)})
+	}
+	if c.Rotation.MaxSizeMB < 0 {
+		errs = append(errs, &ConfigError{Field: "rotation.max_size_mb", Reason: "must not be negative"})
+	}
+	if c.Rotation.MaxBackups < 0 {
+		errs = append(errs, &ConfigError{Field: "rotation.max_backups", Reason: "must not be negative"})
+	}
+	return errors.Join(errs...)
+}
+
+// Build validates the configuration, opens its outputs and returns the configured Logger,
+// created with NewLogger. It never panics: invalid settings are reported as errors.
+// The files opened for the outputs belong to the Logger and are closed by its Close method.
+//
+// Example:
+//
+//	cfg, err := LoadConfig("loggy.json")
+//	if err != nil {
+//		return err
+//	}
+//	logger, err := cfg.Build()
+//	if err != nil {
+//		return err
+//	}
+//	defer logger.Close()
+func (c Config) Build() (*Logger, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	w, closers, err := openOutputs(c.Outputs, c.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	l, err := NewLogger(c.loggerName(), w, c.level(), c.options()...)
+	if err != nil {
+		for _, cl := range closers {
+			cl.Close()
+		}
+		return nil, err
+	}
+	l.closers = closers
+	return l, nil
+}
+
+// Close closes the files opened by Config.Build for the Logger's outputs; entries logged
+// afterwards fail to be written to them. It does nothing for other Loggers, including
+// sub-loggers, and on subsequent calls. Outputs opened by a ConfigWatcher are closed by
+// the watcher instead.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	closers := l.closers
+	l.closers = nil
+	l.mu.Unlock()
+	var errs []error
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loggerName returns the configured name, defaulting to the executable's base name.
+func (c Config) loggerName() string {
+	if c.Name == "" {
+		return filepath.Base(os.Args[0])
+	}
+	return c.Name
+}
+
+// level returns the configured minimum severity, defaulting to DebugIssuer.
+// The configuration must have been validated.
+func (c Config) level() Severity {
+	if c.Level == "" {
+		return DebugIssuer
+	}
+	lv, _ := ParseSeverity(c.Level)
+	return lv
+}
+
+// options translates the configuration into Options. The configuration must have been validated.
+func (c Config) options() []Option {
+	var opts []Option
+	if c.TimeFormat != "" {
+		opts = append(opts, WithTimeFormat(c.TimeFormat))
+	}
+	opts = append(opts, WithUTC(c.UTC))
+	if c.SeverityNames != nil {
+		opts = append(opts, WithSeverityNames(c.SeverityNames))
+	}
+	if strings.EqualFold(c.Encoder, "console") {
+		opts = append(opts, WithColor(ColorAuto))
+	}
+	if c.Caller != "" {
+		opts = append(opts, WithCallerStyle(callerStyleNames[strings.ToLower(c.Caller)]))
+	}
+	return opts
+}
+
+// openOutputs opens the named outputs and combines them into a single writer. It also
+// returns the files it opened so that callers replacing the outputs can close them.
+// On error, any file already opened is closed.
+func openOutputs(outputs []string, rot RotationConfig) (io.Writer, []io.Closer, error) {
+	if len(outputs) == 0 {
+		return os.Stdout, nil, nil
+	}
+	var (
+		writers []io.Writer
+		closers []io.Closer
+	)
+	for _, out := range outputs {
+		switch strings.ToLower(strings.TrimSpace(out)) {
+		case "stdout":
+			writers = append(writers, os.Stdout)
+			continue
+		case "stderr":
+			writers = append(writers, os.Stderr)
+			continue
+		}
+		var (
+			f   io.WriteCloser
+			err error
+		)
+		if rot.MaxSizeMB > 0 {
+			f, err = NewRotatingFile(out, int64(rot.MaxSizeMB)<<20, rot.MaxBackups)
+		} else {
+			f, err = os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		}
+		if err != nil {
+			for _, c := range closers {
+				c.Close()
+			}
+			return nil, nil, fmt.Errorf("loggy: config: outputs: %w", err)
+		}
+		writers = append(writers, f)
+		closers = append(closers, f)
+	}
+	if len(writers) == 1 {
+		return writers[0], closers, nil
+	}
+	return io.MultiWriter(writers...), closers, nil
+}
+
+// splitList splits a comma-separated list, trimming whitespace around each element.
+func splitList(s string) []string {
+	parts := strings.Split(s, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
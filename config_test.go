@@ -0,0 +1,150 @@
+package loggy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseConfigAndBuild verifies that a JSON configuration produces the described Logger.
+func TestParseConfigAndBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg, err := ParseConfig([]byte(`{
+		"name": "svc",
+		"level": "warn",
+		"time_format": "15:04:05",
+		"utc": true,
+		"severity_names": ["D", "I", "W", "E", "F"],
+		"outputs": ["` + filepath.ToSlash(path) + `"],
+		"caller": "none"
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseConfig: %v", err)
+	}
+	logger, err := cfg.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error from Build: %v", err)
+	}
+	if logger.Name() != "svc" || logger.GetLevel() != WarnIssuer {
+		t.Errorf("Unexpected logger: name %q, level %v", logger.Name(), logger.GetLevel())
+	}
+	_ = logger.Info("filtered")
+	_ = logger.Warn("kept")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading output: %v", err)
+	}
+	if strings.Contains(string(data), "filtered") || !strings.HasSuffix(string(data), ": svc:W kept\n") {
+		t.Errorf("Unexpected file contents: %q", data)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Errorf("Unexpected error from Close: %v", err)
+	}
+	if err := logger.Warn("after close"); err == nil {
+		t.Error("Expected writes to fail once the outputs are closed")
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("Expected a second Close to do nothing, got: %v", err)
+	}
+}
+
+// TestParseConfigUnknownKey verifies that typos in keys are rejected.
+func TestParseConfigUnknownKey(t *testing.T) {
+	if _, err := ParseConfig([]byte(`{"levle": "info"}`)); err == nil {
+		t.Error("Expected an error for an unknown key")
+	}
+}
+
+// TestConfigValidateReportsAllErrors verifies that every invalid setting is reported.
+func TestConfigValidateReportsAllErrors(t *testing.T) {
+	cfg := Config{
+		Name:          "bad:name",
+		Level:         "verbose",
+		SeverityNames: []string{"a"},
+		Outputs:       []string{" "},
+		Encoder:       "xml",
+		Caller:        "short",
+		Rotation:      RotationConfig{MaxSizeMB: -1, MaxBackups: -1},
+	}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	want := []string{"name", "level", "severity_names", "outputs", "encoder", "caller", "rotation.max_size_mb", "rotation.max_backups"}
+	for _, field := range want {
+		if !strings.Contains(err.Error(), "config: "+field+":") {
+			t.Errorf("Expected an error for %q, got: %v", field, err)
+		}
+	}
+	var ce *ConfigError
+	if !errors.As(err, &ce) {
+		t.Errorf("Expected errors to be *ConfigError, got %T", err)
+	}
+	if _, err := cfg.Build(); err == nil {
+		t.Error("Expected Build to reject an invalid configuration")
+	}
+	if err := (Config{}).Validate(); err != nil {
+		t.Errorf("Expected the zero Config to be valid, got: %v", err)
+	}
+	if _, err := (Config{Name: "a\rb"}).Build(); !errors.As(err, &ce) || ce.Field != "name" {
+		t.Errorf("Expected a carriage return in the name to be a *ConfigError, got: %v", err)
+	}
+}
+
+// TestConfigApplyEnv verifies LOGGY_* overrides and the reporting of malformed values.
+func TestConfigApplyEnv(t *testing.T) {
+	t.Setenv("LOGGY_NAME", "env-svc")
+	t.Setenv("LOGGY_LEVEL", "error")
+	t.Setenv("LOGGY_OUTPUTS", "stdout, stderr")
+	t.Setenv("LOGGY_ROTATION_MAX_BACKUPS", "3")
+	cfg := Config{Name: "file-svc", Level: "info"}
+	if err := cfg.ApplyEnv(); err != nil {
+		t.Fatalf("Unexpected error from ApplyEnv: %v", err)
+	}
+	if cfg.Name != "env-svc" || cfg.Level != "error" || cfg.Rotation.MaxBackups != 3 {
+		t.Errorf("Unexpected config after ApplyEnv: %+v", cfg)
+	}
+	if len(cfg.Outputs) != 2 || cfg.Outputs[1] != "stderr" {
+		t.Errorf("Unexpected outputs: %q", cfg.Outputs)
+	}
+
+	t.Setenv("LOGGY_UTC", "maybe")
+	t.Setenv("LOGGY_ROTATION_MAX_SIZE_MB", "ten")
+	_, err := ConfigFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "LOGGY_UTC") || !strings.Contains(err.Error(), "LOGGY_ROTATION_MAX_SIZE_MB") {
+		t.Errorf("Expected both malformed variables to be reported, got: %v", err)
+	}
+}
+
+// TestRotatingFile verifies that files are rotated by size and backups are bounded.
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewRotatingFile: %v", err)
+	}
+	for _, s := range []string{"aaaaaa\n", "bbbbbb\n", "cccccc\n", "dddddd\n"} {
+		if _, err := f.Write([]byte(s)); err != nil {
+			t.Fatalf("Unexpected error from Write: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("Unexpected error from Close: %v", err)
+	}
+	want := map[string]string{path: "dddddd\n", path + ".1": "cccccc\n", path + ".2": "bbbbbb\n"}
+	for p, content := range want {
+		data, err := os.ReadFile(p)
+		if err != nil || string(data) != content {
+			t.Errorf("Expected %s to contain %q, got %q (%v)", p, content, data, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected backups beyond the limit to be removed")
+	}
+	if _, err := f.Write([]byte("late")); err == nil {
+		t.Error("Expected writes after Close to fail")
+	}
+}
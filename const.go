@@ -1,8 +1,11 @@
 package loggy
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"regexp"
+	"time"
 )
 
 // Predefined severity levels for logging.
@@ -26,6 +29,9 @@ const (
 	DisableIssuer
 )
 
+// registry holds every Logger registered by name, as enumerated by Loggers.
+var registry = &loggerRegistry{loggers: make(map[string]*Logger)}
+
 // Default is a pre-configured Logger instance intended for general use.
 // It is configured with the current executable's base name as the logger name,
 // outputs to os.Stdout, and is set to log messages at the Debug level.
@@ -35,3 +41,192 @@ var Default = New(
 	DebugIssuer,
 	WithSeverityNames([]string{"debug:", "info:", "warn:", "error:", "fatal:"}),
 )
+
+// Layout settings set by an Option, recorded so that a sub-logger only overrides those
+// of its parent's settings.
+const (
+	setTimeFormat layoutFields = 1 << iota
+	setUTC
+	setSeverityNames
+	setColorMode
+	setColorTheme
+	setCallerStyle
+	setCallerFunc
+)
+
+// Supported colour modes for console output.
+const (
+	// ColorNever disables colourised output regardless of the writer or environment.
+	ColorNever ColorMode = iota
+
+	// ColorAuto colourises output only when the writer is a terminal, honouring the
+	// NO_COLOR and FORCE_COLOR environment variables.
+	ColorAuto
+
+	// ColorAlways colourises output unconditionally, even when writing to files or pipes.
+	ColorAlways
+)
+
+// DefaultColorTheme is the colour theme used when colourised output is enabled and
+// no custom theme has been provided through WithColorTheme.
+var DefaultColorTheme = ColorTheme{
+	DebugIssuer: "36",   // cyan
+	InfoIssuer:  "32",   // green
+	WarnIssuer:  "33",   // yellow
+	ErrorIssuer: "31",   // red
+	FatalIssuer: "1;31", // bold red
+}
+
+// callerColor is the ANSI SGR parameter applied to the caller location (faint).
+const callerColor = "2"
+
+// Supported caller rendering styles.
+const (
+	// CallerBase renders the base name of the source file (e.g. "handler.go:42").
+	CallerBase CallerStyle = iota
+
+	// CallerModule renders the path relative to the main module root (e.g. "internal/api/handler.go:42").
+	// Files outside the main module are rendered with their full package path.
+	CallerModule
+
+	// CallerFull renders the absolute file path as recorded by the compiler.
+	CallerFull
+
+	// CallerPackage renders the last element of the package path and the file name (e.g. "api/handler.go:42").
+	CallerPackage
+
+	// CallerNone disables the caller lookup entirely, avoiding its runtime cost.
+	CallerNone
+)
+
+// Supported redaction modes.
+const (
+	// RedactMask replaces sensitive values with a fixed placeholder.
+	RedactMask RedactMode = iota
+
+	// RedactHash replaces sensitive values with a truncated SHA-256 digest, so that equal
+	// values can still be correlated across entries without revealing them. Note that
+	// low-entropy values such as emails may be recovered from an unsalted digest by brute force.
+	RedactHash
+
+	// RedactDrop removes sensitive values entirely: matches are deleted from the message,
+	// and affected fields are removed from the entry.
+	RedactDrop
+)
+
+// redactedMask is the placeholder written in place of values redacted with RedactMask.
+const redactedMask = "[REDACTED]"
+
+// Predefined redaction rules for common secret and PII formats.
+var (
+	// BearerTokenRule matches HTTP bearer credentials such as "Bearer eyJhbGciOi...".
+	BearerTokenRule = RedactRule{
+		Name:    "bearer",
+		Pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	}
+
+	// JWTRule matches JSON Web Tokens appearing outside of an Authorization header.
+	JWTRule = RedactRule{
+		Name:    "jwt",
+		Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	}
+
+	// CreditCardRule matches 13 to 19 digit card numbers, optionally separated by spaces or
+	// dashes, that pass the Luhn checksum.
+	CreditCardRule = RedactRule{
+		Name:    "credit-card",
+		Pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Verify:  luhnValid,
+	}
+
+	// EmailRule matches email addresses.
+	EmailRule = RedactRule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	}
+)
+
+// DefaultDenyKeys lists field keys commonly used for credentials.
+var DefaultDenyKeys = []string{"password", "passwd", "secret", "token", "authorization", "api_key", "apikey", "cookie"}
+
+// maxLineLength bounds the size of a single line buffered by the writer returned from
+// Logger.Writer. Longer lines are split into several entries of at most this many bytes.
+const maxLineLength = 64 << 10
+
+// Errors returned by NewLogger for invalid arguments.
+var (
+	ErrInvalidName  = errors.New("loggy: invalid logger name")
+	ErrNilWriter    = errors.New("loggy: nil writer")
+	ErrInvalidLevel = errors.New("loggy: invalid severity level")
+	ErrNilOption    = errors.New("loggy: nil option")
+)
+
+// ErrWriterClosed is returned by writes to a writer obtained from Logger.Writer after it was closed.
+var ErrWriterClosed = errors.New("loggy: write to closed writer")
+
+// inheritLevel is stored as the minimum level of sub-loggers that defer to their parent's level.
+const inheritLevel = ^uint32(0)
+
+// defaultErrorReportInterval is the default minimum interval between two reports of failed writes.
+const defaultErrorReportInterval = 10 * time.Second
+
+// defaultTimeFormat is the timestamp layout used when none is configured.
+const defaultTimeFormat = "2006-01-02 15:04:05.000000"
+
+// defaultSeverityNames returns the labels used when none are configured.
+func defaultSeverityNames() []string {
+	return []string{"debug:", "info:", "warn:", "error:", "fatal:"}
+}
+
+// Supported access log formats.
+const (
+	// AccessLogStructured writes "METHOD /path" followed by key=value fields.
+	AccessLogStructured AccessLogFormat = iota
+
+	// AccessLogCommon writes the Apache Common Log Format.
+	AccessLogCommon
+
+	// AccessLogCombined writes the Apache Combined Log Format (Common plus referer and user agent).
+	AccessLogCombined
+)
+
+// DefaultRequestIDHeader is the header used by HTTPMiddleware to read and propagate request IDs.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of a request ID accepted from a request header.
+const maxRequestIDLength = 128
+
+// Context keys used by this package.
+const (
+	requestIDKey contextKey = iota
+	loggerKey
+	traceKey
+)
+
+// W3C Trace Context header names.
+const (
+	TraceparentHeader = "Traceparent"
+	TracestateHeader  = "Tracestate"
+)
+
+// maxTracestateLength is the length beyond which an incoming tracestate is discarded.
+const maxTracestateLength = 512
+
+// contextFields holds the context keys whose values are added as fields by the Ctx methods.
+var contextFields = func() *contextRegistry {
+	r := new(contextRegistry)
+	r.fields.Store(&[]contextField{{name: "request_id", key: requestIDKey}})
+	return r
+}()
+
+// DefaultRedactedHeaders lists the headers whose values NewTransport never writes when
+// capturing request and response headers.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// metrics holds the entry counters of every Logger name, as exposed by Metrics.
+var metrics = &metricsRegistry{byName: make(map[string]*loggerCounters)}
+
+// noCaller is passed to Logger.log as the stack of entries logged on behalf of code that
+// is not on the call stack, such as HTTP access logs and panics without a usable stack,
+// so that no misleading caller location is rendered.
+var noCaller = []Frame{}
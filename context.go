@@ -0,0 +1,162 @@
+package loggy
+
+import "context"
+
+// NewContext returns a copy of ctx carrying l, for retrieval with FromContext.
+//
+// Example:
+//
+//	ctx = NewContext(ctx, logger)
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the Logger stored in ctx by NewContext, or Default if there is none.
+//
+// Example:
+//
+//	FromContext(ctx).InfoCtx(ctx, "order created")
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerKey).(*Logger); ok && l != nil {
+		return l
+	}
+	return Default
+}
+
+// RegisterContextKey makes the Ctx methods add the value stored in the context under key
+// as a field named name. Registering a name again replaces its key. The request ID
+// (see RequestIDFromContext) is registered as "request_id" by default.
+// It is safe to call concurrently with logging, but is meant to be called during start-up.
+//
+// Example:
+//
+//	type tenantKey struct{}
+//	RegisterContextKey("tenant", tenantKey{})
+//	ctx = context.WithValue(ctx, tenantKey{}, "acme")
+//	logger.InfoCtx(ctx, "quota exceeded") // ... quota exceeded tenant=acme
+func RegisterContextKey(name string, key interface{}) {
+	contextFields.mu.Lock()
+	defer contextFields.mu.Unlock()
+	fields := append([]contextField(nil), *contextFields.fields.Load()...)
+	for i := range fields {
+		if fields[i].name == name {
+			fields[i].key = key
+			contextFields.fields.Store(&fields)
+			return
+		}
+	}
+	fields = append(fields, contextField{name: name, key: key})
+	contextFields.fields.Store(&fields)
+}
+
+// contextValues appends to msg a Field for every registered key with a value in ctx,
+// followed by trace_id and span_id if ctx carries a trace context. Empty strings are skipped.
+func contextValues(ctx context.Context, msg []interface{}) []interface{} {
+	if ctx == nil {
+		return msg
+	}
+	for _, f := range *contextFields.fields.Load() {
+		v := ctx.Value(f.key)
+		if v == nil {
+			continue
+		}
+		if s, ok := v.(string); ok && s == "" {
+			continue
+		}
+		msg = append(msg, F(f.name, v))
+	}
+	if tc, ok := TraceFromContext(ctx); ok {
+		msg = append(msg, F("trace_id", tc.TraceIDString()), F("span_id", tc.SpanIDString()))
+	}
+	return msg
+}
+
+// LogCtx logs at the given severity like Log, adding the registered context values of ctx
+// as fields.
+func (l *Logger) LogCtx(ctx context.Context, level Severity, msg ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(level) {
+		return nil
+	}
+	return l.Log(level, contextValues(ctx, msg)...)
+}
+
+// DebugCtx logs at the debug level, adding the registered context values of ctx as fields.
+func (l *Logger) DebugCtx(ctx context.Context, msg ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(DebugIssuer) {
+		return nil
+	}
+	return l.Log(DebugIssuer, contextValues(ctx, msg)...)
+}
+
+// InfoCtx logs at the info level, adding the registered context values of ctx as fields.
+//
+// Example:
+//
+//	logger.InfoCtx(r.Context(), "order created", F("order", id)) // ... order created order=42 request_id=9f86d0...
+func (l *Logger) InfoCtx(ctx context.Context, msg ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(InfoIssuer) {
+		return nil
+	}
+	return l.Log(InfoIssuer, contextValues(ctx, msg)...)
+}
+
+// WarnCtx logs at the warn level, adding the registered context values of ctx as fields.
+func (l *Logger) WarnCtx(ctx context.Context, msg ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(WarnIssuer) {
+		return nil
+	}
+	return l.Log(WarnIssuer, contextValues(ctx, msg)...)
+}
+
+// ErrorCtx logs at the error level, adding the registered context values of ctx as fields.
+func (l *Logger) ErrorCtx(ctx context.Context, msg ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(ErrorIssuer) {
+		return nil
+	}
+	return l.Log(ErrorIssuer, contextValues(ctx, msg)...)
+}
+
+// DebugCtx logs at the debug level through the Logger stored in ctx (see FromContext).
+func DebugCtx(ctx context.Context, msg ...interface{}) error {
+	l := FromContext(ctx)
+	l.testHelper().Helper()
+	if !l.admit(DebugIssuer) {
+		return nil
+	}
+	return l.Log(DebugIssuer, contextValues(ctx, msg)...)
+}
+
+// InfoCtx logs at the info level through the Logger stored in ctx (see FromContext).
+func InfoCtx(ctx context.Context, msg ...interface{}) error {
+	l := FromContext(ctx)
+	l.testHelper().Helper()
+	if !l.admit(InfoIssuer) {
+		return nil
+	}
+	return l.Log(InfoIssuer, contextValues(ctx, msg)...)
+}
+
+// WarnCtx logs at the warn level through the Logger stored in ctx (see FromContext).
+func WarnCtx(ctx context.Context, msg ...interface{}) error {
+	l := FromContext(ctx)
+	l.testHelper().Helper()
+	if !l.admit(WarnIssuer) {
+		return nil
+	}
+	return l.Log(WarnIssuer, contextValues(ctx, msg)...)
+}
+
+// ErrorCtx logs at the error level through the Logger stored in ctx (see FromContext).
+func ErrorCtx(ctx context.Context, msg ...interface{}) error {
+	l := FromContext(ctx)
+	l.testHelper().Helper()
+	if !l.admit(ErrorIssuer) {
+		return nil
+	}
+	return l.Log(ErrorIssuer, contextValues(ctx, msg)...)
+}
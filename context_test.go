@@ -0,0 +1,77 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestFromContext verifies that the stored Logger is returned, falling back to Default.
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != Default {
+		t.Error("Expected Default for a context without a Logger")
+	}
+	logger := New(": test-service:", new(bytes.Buffer), DebugIssuer)
+	if FromContext(NewContext(context.Background(), logger)) != logger {
+		t.Error("Expected the Logger stored with NewContext")
+	}
+}
+
+// ctxTenantKey is a context key used to test RegisterContextKey.
+type ctxTenantKey struct{}
+
+// TestCtxMethods verifies that registered context values are added as fields and that the
+// caller location is that of the call site.
+func TestCtxMethods(t *testing.T) {
+	RegisterContextKey("tenant", ctxTenantKey{})
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, InfoIssuer)
+	ctx := ContextWithRequestID(context.Background(), "req-7")
+	ctx = context.WithValue(ctx, ctxTenantKey{}, "acme")
+
+	if err := logger.InfoCtx(ctx, "created", F("order", 42)); err != nil {
+		t.Errorf("Unexpected error from InfoCtx: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), ": created order=42 request_id=req-7 tenant=acme\n") {
+		t.Errorf("Expected context fields after explicit fields, got: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), " context_test.go:") {
+		t.Errorf("Expected the caller to be the test file, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := logger.DebugCtx(ctx, "filtered"); err != nil {
+		t.Errorf("Unexpected error from DebugCtx: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug entry to be filtered, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := ErrorCtx(NewContext(context.Background(), logger), "no values"); err != nil {
+		t.Errorf("Unexpected error from ErrorCtx: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), ": no values\n") || !strings.Contains(buf.String(), "error:") || !strings.Contains(buf.String(), " context_test.go:") {
+		t.Errorf("Expected package-level call through the context Logger, got: %q", buf.String())
+	}
+}
+
+// TestRegisterContextKeyReplaces verifies that registering a name again replaces its key.
+func TestRegisterContextKeyReplaces(t *testing.T) {
+	type first struct{}
+	type second struct{}
+	RegisterContextKey("registered", first{})
+	RegisterContextKey("registered", second{})
+	ctx := context.WithValue(context.WithValue(context.Background(), first{}, "a"), second{}, "b")
+	fields := contextValues(ctx, nil)
+	var got []string
+	for _, f := range fields {
+		if f := f.(Field); f.Key == "registered" {
+			got = append(got, f.Value.(string))
+		}
+	}
+	if len(got) != 1 || got[0] != "b" {
+		t.Errorf("Expected a single field from the replacing key, got: %v", got)
+	}
+}
@@ -0,0 +1,186 @@
+package loggy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxErrorNodes bounds the number of errors described for a single ErrorChain, protecting
+// against pathologically deep or wide trees.
+const maxErrorNodes = 64
+
+// Err returns a Field named "error" that logs err with its full chain. In the text layout
+// it is written as:
+//
+//	error="<message>" error.chain="<types>" error.<key>=<value>...
+//
+// where the chain lists the concrete type of each wrapped error, separated by " -> ", with
+// the branches of errors.Join (or any Unwrap() []error) between brackets, and the fields are
+// those of every error in the tree implementing FieldError. The stack of the innermost
+// error implementing StackTracer is appended as continuation lines. A nil err is logged as
+// error=<nil>.
+//
+// Example:
+//
+//	logger.Error("loading config failed", Err(err))
+//	// ... loading config failed error="read config: open app.json: permission denied"
+//	//     error.chain="*fmt.wrapError -> *fs.PathError -> syscall.Errno"
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr is like Err with a custom field name, for entries carrying several errors.
+func NamedErr(key string, err error) Field {
+	if err == nil {
+		return Field{Key: key}
+	}
+	return Field{Key: key, Value: ErrorChain{Err: err}}
+}
+
+// Error implements the error interface, so that ErrorChain values are treated as errors
+// by hooks and redaction.
+func (c ErrorChain) Error() string {
+	if c.info != nil {
+		return c.info.Message
+	}
+	return c.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (c ErrorChain) Unwrap() error {
+	return c.Err
+}
+
+// Info describes the wrapped error tree for structured consumers such as hooks.
+func (c ErrorChain) Info() ErrorInfo {
+	if c.info != nil {
+		return *c.info
+	}
+	n := 0
+	return describeError(c.Err, &n)
+}
+
+// describeError builds the ErrorInfo of err, counting visited errors in n.
+func describeError(err error, n *int) ErrorInfo {
+	*n++
+	info := ErrorInfo{Type: fmt.Sprintf("%T", err), Message: err.Error()}
+	if fe, ok := err.(FieldError); ok {
+		info.Fields = fe.LogFields()
+	}
+	if st, ok := err.(StackTracer); ok {
+		info.Stack = st.StackTrace()
+	}
+	var causes []error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); cause != nil {
+			causes = []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		causes = u.Unwrap()
+	}
+	for _, cause := range causes {
+		if cause == nil {
+			continue
+		}
+		if *n >= maxErrorNodes {
+			info.Causes = append(info.Causes, ErrorInfo{Type: "...", Message: "..."})
+			break
+		}
+		info.Causes = append(info.Causes, describeError(cause, n))
+	}
+	return info
+}
+
+// Chain renders the concrete types of the tree as described for Err.
+func (info ErrorInfo) Chain() string {
+	var b strings.Builder
+	info.writeChain(&b)
+	return b.String()
+}
+
+// writeChain writes the type of info followed by its causes.
+func (info ErrorInfo) writeChain(b *strings.Builder) {
+	b.WriteString(info.Type)
+	switch len(info.Causes) {
+	case 0:
+	case 1:
+		b.WriteString(" -> ")
+		info.Causes[0].writeChain(b)
+	default:
+		b.WriteByte('[')
+		for i, cause := range info.Causes {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			cause.writeChain(b)
+		}
+		b.WriteByte(']')
+	}
+}
+
+// AllFields returns the fields of every error in the tree, outermost first.
+func (info ErrorInfo) AllFields() []Field {
+	fields := info.Fields
+	for _, cause := range info.Causes {
+		fields = append(fields[:len(fields):len(fields)], cause.AllFields()...)
+	}
+	return fields
+}
+
+// InnermostStack returns the stack of the deepest error in the tree that has one, which
+// is the closest to where the failure originated.
+func (info ErrorInfo) InnermostStack() []Frame {
+	for _, cause := range info.Causes {
+		if stack := cause.InnermostStack(); stack != nil {
+			return stack
+		}
+	}
+	return info.Stack
+}
+
+// writeErrorField writes an ErrorChain field as described for Err.
+func writeErrorField(b *buffer, key string, c ErrorChain) {
+	info := c.Info()
+	writeKey(b, key)
+	b.WriteByte('=')
+	writeValue(b, info.Message)
+	b.WriteByte(' ')
+	writeSubKey(b, key, "chain")
+	b.WriteByte('=')
+	writeValue(b, info.Chain())
+	for _, f := range info.AllFields() {
+		b.WriteByte(' ')
+		writeSubKey(b, key, f.Key)
+		b.WriteByte('=')
+		writeValue(b, f.Value)
+	}
+}
+
+// writeSubKey writes the key "<key>.<sub>", quoting it when necessary.
+func writeSubKey(b *buffer, key, sub string) {
+	if needsQuoting(key) || needsQuoting(sub) {
+		b.WriteQuoted(key + "." + sub)
+		return
+	}
+	b.WriteString(key)
+	b.WriteByte('.')
+	b.WriteString(sub)
+}
+
+// writeErrorStacks writes the stack of each ErrorChain field as continuation lines,
+// introduced by a "\t<key>.stack:" line.
+func writeErrorStacks(b *buffer, fields []Field) {
+	for _, f := range fields {
+		c, ok := f.Value.(ErrorChain)
+		if !ok {
+			continue
+		}
+		if stack := c.Info().InnermostStack(); len(stack) > 0 {
+			b.WriteByte('\t')
+			b.WriteString(f.Key)
+			b.WriteString(".stack:\n")
+			writeStack(b, stack)
+		}
+	}
+}
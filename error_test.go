@@ -0,0 +1,122 @@
+package loggy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+)
+
+// tracedError is an error exposing a stack trace and fields, as used by error libraries.
+type tracedError struct {
+	msg   string
+	stack []Frame
+}
+
+func (e *tracedError) Error() string       { return e.msg }
+func (e *tracedError) StackTrace() []Frame { return e.stack }
+func (e *tracedError) LogFields() []Field  { return []Field{F("user", 42)} }
+
+// countingError counts the calls to its methods, returning a different message each time.
+type countingError struct{ messages, fields, stacks int }
+
+func (e *countingError) Error() string {
+	e.messages++
+	return fmt.Sprintf("attempt %d", e.messages)
+}
+func (e *countingError) LogFields() []Field { e.fields++; return []Field{F("n", e.fields)} }
+func (e *countingError) StackTrace() []Frame {
+	e.stacks++
+	return []Frame{{Function: "app.run", File: "app.go", Line: 1}}
+}
+
+// TestErrDescribedOnce verifies that each error of a chain is described once per entry,
+// for its fields, its stack and hooks inspecting it.
+func TestErrDescribedOnce(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer,
+		WithHook(HookFunc(func(e *Entry) bool {
+			_ = e.Fields[0].Value.(ErrorChain).Info()
+			return true
+		})))
+	e := &countingError{}
+	err := fmt.Errorf("run: %w", e) // calls e.Error once
+	_ = logger.Error("failed", Err(err))
+	if e.messages != 2 || e.fields != 1 || e.stacks != 1 {
+		t.Errorf("Expected the error to be described once, got: %+v", *e)
+	}
+	if !strings.Contains(buf.String(), `error="run: attempt 1"`) || !strings.Contains(buf.String(), "error.n=1") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+// TestErrChain verifies the rendering of a wrapped chain with concrete types.
+func TestErrChain(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	_, err := os.Open("/does/not/exist")
+	err = fmt.Errorf("load config: %w", err)
+	if e := logger.Error("startup failed", Err(err)); e != nil {
+		t.Errorf("Unexpected error from Error: %v", e)
+	}
+	want := ` error="load config: open /does/not/exist: no such file or directory" error.chain="*fmt.wrapError -> *fs.PathError -> syscall.Errno"` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected output to end with %q, got: %q", want, buf.String())
+	}
+}
+
+// TestErrJoin verifies that errors.Join trees are expanded.
+func TestErrJoin(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	err := errors.Join(errors.New("first"), fmt.Errorf("second: %w", fs.ErrNotExist))
+	if e := logger.Error("validation failed", NamedErr("cause", err)); e != nil {
+		t.Errorf("Unexpected error from Error: %v", e)
+	}
+	want := ` cause="first\nsecond: file does not exist" cause.chain="*errors.joinError[*errors.errorString, *fmt.wrapError -> *errors.errorString]"` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected output to end with %q, got: %q", want, buf.String())
+	}
+}
+
+// TestErrStackAndFields verifies that fields and stacks exposed through interfaces are logged.
+func TestErrStackAndFields(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	inner := &tracedError{msg: "disk full", stack: []Frame{{Function: "store.(*DB).Write", File: "/src/store/db.go", Line: 88}}}
+	err := fmt.Errorf("save: %w", inner)
+	if e := logger.Error("request failed", Err(err)); e != nil {
+		t.Errorf("Unexpected error from Error: %v", e)
+	}
+	want := ` error="save: disk full" error.chain="*fmt.wrapError -> *loggy.tracedError" error.user=42` + "\n" +
+		"\terror.stack:\n\tstore.(*DB).Write\n\t\t/src/store/db.go:88\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected output to end with %q, got: %q", want, buf.String())
+	}
+
+	info := Err(err).Value.(ErrorChain).Info()
+	if info.Type != "*fmt.wrapError" || len(info.Causes) != 1 || info.Causes[0].Type != "*loggy.tracedError" {
+		t.Errorf("Unexpected error info: %+v", info)
+	}
+	if len(info.InnermostStack()) != 1 || len(info.AllFields()) != 1 {
+		t.Errorf("Expected the inner stack and fields to be found, got: %+v", info)
+	}
+	if !errors.Is(Err(err).Value.(ErrorChain), inner) {
+		t.Error("Expected ErrorChain to unwrap to the logged error")
+	}
+}
+
+// TestErrNil verifies that a nil error is logged as <nil>.
+func TestErrNil(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	if e := logger.Info("done", Err(nil)); e != nil {
+		t.Errorf("Unexpected error from Info: %v", e)
+	}
+	if !strings.HasSuffix(buf.String(), " done error=<nil>\n") {
+		t.Errorf("Expected error=<nil>, got: %q", buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+package loggy
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithErrorHandler returns an Option that reports failed writes to fn instead of the
+// fallback writer. Reports are rate-limited (see WithErrorReportInterval): fn receives a
+// *WriteError carrying the writer's error and the number of failures suppressed since the
+// previous report. fn must not log through the same Logger.
+//
+// Example:
+//
+//	logger := New(": my-service:", file, DebugIssuer, WithErrorHandler(func(err error) {
+//		alerts.Notify(err)
+//	}))
+func WithErrorHandler(fn func(error)) Option {
+	return func(l *Logger) {
+		l.errorHandler = fn
+	}
+}
+
+// WithFallbackWriter returns an Option that writes entries to w when the Logger's writer
+// fails, so that they are not lost, and reports the failures there too unless an error
+// handler is set. Log then returns an error only if the fallback fails as well.
+//
+// Example:
+//
+//	logger := New(": my-service:", file, DebugIssuer, WithFallbackWriter(os.Stderr))
+func WithFallbackWriter(w io.Writer) Option {
+	return func(l *Logger) {
+		l.fallback = w
+	}
+}
+
+// WithErrorReportInterval returns an Option that sets the minimum interval between two
+// reports of failed writes, so that a broken disk does not cause a storm of reports.
+// The default is 10 seconds; zero reports every failure.
+func WithErrorReportInterval(d time.Duration) Option {
+	return func(l *Logger) {
+		if d >= 0 {
+			l.errorInterval = d
+		}
+	}
+}
+
+// Error implements the error interface.
+func (e *WriteError) Error() string {
+	msg := fmt.Sprintf("loggy: %s: write failed: %v", e.Logger, e.Err)
+	if e.Suppressed > 0 {
+		msg += fmt.Sprintf(" (%d similar errors suppressed)", e.Suppressed)
+	}
+	return msg
+}
+
+// Unwrap returns the writer's error.
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// writeFailed handles a failed write of the encoded entry b: it reports err, subject to
+// rate limiting, and retries on the fallback writer. It returns err unless the fallback
+// succeeded.
+func (l *Logger) writeFailed(err error, b []byte) error {
+	l.testHelper().Helper()
+	l.reportWriteError(err)
+	if l.fallback == nil {
+		return err
+	}
+	if l.writeLocked(l.fallback, b) != nil {
+		return err
+	}
+	return nil
+}
+
+// reportWriteError reports err to the error handler or fallback writer, unless another
+// failure was reported less than errorInterval ago, in which case it is only counted.
+func (l *Logger) reportWriteError(err error) {
+	l.testHelper().Helper()
+	if l.errorHandler == nil && l.fallback == nil {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := l.failures.last.Load()
+	if last != 0 && now-last < int64(l.errorInterval) || !l.failures.last.CompareAndSwap(last, now) {
+		l.failures.suppressed.Add(1)
+		return
+	}
+	we := &WriteError{Logger: l.Name(), Err: err, Suppressed: l.failures.suppressed.Swap(0)}
+	if l.errorHandler != nil {
+		l.errorHandler(we)
+		return
+	}
+	_ = l.writeLocked(l.fallback, []byte(we.Error()+"\n"))
+}
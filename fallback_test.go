@@ -0,0 +1,60 @@
+package loggy
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFallbackWriter verifies that entries are written to the fallback and that the
+// failure is reported there once per interval.
+func TestFallbackWriter(t *testing.T) {
+	fallback := new(bytes.Buffer)
+	logger := New(": fallback-test:", failingWriter{}, DebugIssuer, WithFallbackWriter(fallback))
+	for i := 0; i < 3; i++ {
+		if err := logger.Info("saved"); err != nil {
+			t.Errorf("Expected no error when the fallback succeeds, got: %v", err)
+		}
+	}
+	output := fallback.String()
+	if n := strings.Count(output, "loggy: fallback-test: write failed: disk full\n"); n != 1 {
+		t.Errorf("Expected a single failure report, got %d in: %s", n, output)
+	}
+	if n := strings.Count(output, ": saved\n"); n != 3 {
+		t.Errorf("Expected every entry on the fallback, got %d in: %s", n, output)
+	}
+
+	broken := New(": fallback-test:", failingWriter{}, DebugIssuer, WithFallbackWriter(failingWriter{}))
+	if err := broken.Info("lost"); err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the writer's error when the fallback fails too, got: %v", err)
+	}
+}
+
+// TestErrorHandler verifies the rate-limited reports delivered to the error handler.
+func TestErrorHandler(t *testing.T) {
+	var reports []error
+	logger := New(": handler-test:", failingWriter{}, DebugIssuer,
+		WithErrorHandler(func(err error) { reports = append(reports, err) }),
+		WithErrorReportInterval(time.Hour))
+	for i := 0; i < 5; i++ {
+		if err := logger.Warn("lost"); err == nil {
+			t.Error("Expected the write error without a fallback")
+		}
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Expected a single report within the interval, got: %v", reports)
+	}
+	var we *WriteError
+	if !errors.As(reports[0], &we) || we.Logger != "handler-test" || we.Err.Error() != "disk full" {
+		t.Errorf("Unexpected report: %#v", reports[0])
+	}
+
+	// Once the interval has elapsed, the next report carries the suppressed count.
+	logger.failures.last.Store(time.Now().Add(-2 * time.Hour).UnixNano())
+	logger.Warn("lost")
+	if len(reports) != 2 || !strings.HasSuffix(reports[1].Error(), "write failed: disk full (4 similar errors suppressed)") {
+		t.Errorf("Expected a report of the suppressed failures, got: %v", reports)
+	}
+}
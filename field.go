@@ -0,0 +1,94 @@
+package loggy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// F returns a Field with the given key and value for use as a log argument.
+//
+// Example:
+//
+//	logger.Info("request served", F("status", 200), F("path", "/healthz"))
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// splitFields separates Field arguments from the message components.
+// The input slice is returned unchanged if it contains no fields. ErrorChain values are
+// described once here, so that redaction, hooks and rendering all use the same ErrorInfo
+// without calling the methods of each error again.
+func splitFields(msg []interface{}) ([]interface{}, []Field) {
+	n := 0
+	for _, m := range msg {
+		if _, ok := m.(Field); ok {
+			n++
+		}
+	}
+	if n == 0 {
+		return msg, nil
+	}
+	fields := make([]Field, 0, n)
+	rest := make([]interface{}, 0, len(msg)-n)
+	for _, m := range msg {
+		if f, ok := m.(Field); ok {
+			if c, ok := f.Value.(ErrorChain); ok && c.info == nil {
+				info := c.Info()
+				f.Value = ErrorChain{Err: c.Err, info: &info}
+			}
+			fields = append(fields, f)
+		} else {
+			rest = append(rest, m)
+		}
+	}
+	return rest, fields
+}
+
+// writeFields writes each field as " key=value". Keys and values that are empty or contain
+// whitespace, quotes or '=' are quoted so that the line remains unambiguous.
+func writeFields(b *buffer, fields []Field) {
+	for _, f := range fields {
+		b.WriteByte(' ')
+		if c, ok := f.Value.(ErrorChain); ok {
+			writeErrorField(b, f.Key, c)
+			continue
+		}
+		writeKey(b, f.Key)
+		b.WriteByte('=')
+		writeValue(b, f.Value)
+	}
+}
+
+// writeKey writes a field key, quoting it when necessary.
+func writeKey(b *buffer, key string) {
+	if needsQuoting(key) {
+		b.WriteQuoted(key)
+		return
+	}
+	b.WriteString(key)
+}
+
+// needsQuoting reports whether s must be quoted to be parsed back from a key=value pair.
+func needsQuoting(s string) bool {
+	return s == "" || strings.ContainsAny(s, " \t\r\n\"=")
+}
+
+// writeValue writes a single field value, quoting it when necessary.
+func writeValue(b *buffer, v interface{}) {
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case error:
+		s = t.Error()
+	case fmt.Stringer:
+		s = t.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if needsQuoting(s) {
+		b.WriteQuoted(s)
+		return
+	}
+	b.WriteString(s)
+}
@@ -0,0 +1,55 @@
+package loggy
+
+// Fire calls f(e).
+func (f HookFunc) Fire(e *Entry) bool {
+	return f(e)
+}
+
+// WithHook returns an Option that registers a Hook to run on every entry before it is
+// encoded. The hook is only invoked for the given severities; if none are given, it is
+// invoked for all of them. Hooks run in registration order and only for entries that
+// pass the logger's level filter.
+//
+// Example:
+//
+//	host, _ := os.Hostname()
+//	logger := New(": my-service:", os.Stdout, DebugIssuer,
+//		WithHook(HookFunc(func(e *Entry) bool {
+//			e.Fields = append(e.Fields, F("host", host))
+//			return true
+//		})),
+//		WithHook(alerts, ErrorIssuer, FatalIssuer))
+func WithHook(h Hook, levels ...Severity) Option {
+	return func(l *Logger) {
+		if h == nil {
+			return
+		}
+		var mask uint32
+		for _, lv := range levels {
+			if lv < DisableIssuer {
+				mask |= 1 << lv
+			}
+		}
+		if len(levels) == 0 {
+			mask = 1<<DisableIssuer - 1
+		}
+		l.hooks = append(l.hooks, hookBinding{hook: h, levels: mask})
+	}
+}
+
+// fireHooks runs the hooks registered for the entry's severity and returns the possibly
+// modified entry along with whether it should still be written. An entry whose level was
+// changed by a hook to an invalid severity is dropped. The entry is taken by value so that
+// it only moves to the heap when hooks are actually registered.
+func (l *Logger) fireHooks(e Entry) (Entry, bool) {
+	level := e.Level
+	for _, hb := range l.hooks {
+		if hb.levels&(1<<level) == 0 {
+			continue
+		}
+		if !hb.hook.Fire(&e) {
+			return e, false
+		}
+	}
+	return e, e.Level < DisableIssuer
+}
@@ -0,0 +1,114 @@
+package loggy
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestHookEnrichesEntry verifies that hooks receive the entry and can add fields.
+func TestHookEnrichesEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	var seen Entry
+	logger := New(": test-service:", buf, DebugIssuer, WithHook(HookFunc(func(e *Entry) bool {
+		seen = *e
+		e.Fields = append(e.Fields, F("host", "node-1"))
+		return true
+	})))
+	if err := logger.Info("request served", F("status", 200)); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if seen.Level != InfoIssuer || seen.Logger != "test-service" || seen.Message != "request served" {
+		t.Errorf("Unexpected entry passed to hook: %+v", seen)
+	}
+	if !strings.HasSuffix(seen.Caller.File, "hook_test.go") {
+		t.Errorf("Expected caller to point at the test file, got: %+v", seen.Caller)
+	}
+	if len(seen.Fields) != 1 || seen.Fields[0] != F("status", 200) {
+		t.Errorf("Expected the status field, got: %+v", seen.Fields)
+	}
+	if !strings.HasSuffix(buf.String(), "request served status=200 host=node-1\n") {
+		t.Errorf("Expected enriched output, got: %q", buf.String())
+	}
+}
+
+// TestHookVeto verifies that a hook returning false prevents the entry from being written.
+func TestHookVeto(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithHook(HookFunc(func(e *Entry) bool {
+		return !strings.Contains(e.Message, "noisy")
+	})))
+	_ = logger.Info("noisy message")
+	if buf.Len() != 0 {
+		t.Errorf("Expected vetoed entry to be dropped, got: %s", buf.String())
+	}
+	_ = logger.Info("useful message")
+	if !strings.Contains(buf.String(), "useful message") {
+		t.Errorf("Expected entry to be written, got: %s", buf.String())
+	}
+}
+
+// TestHookLevels verifies that hooks only run for their registered severities.
+func TestHookLevels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	var count int
+	logger := New(": test-service:", buf, DebugIssuer, WithHook(HookFunc(func(e *Entry) bool {
+		count++
+		return true
+	}), ErrorIssuer, FatalIssuer))
+	_ = logger.Debug("debug")
+	_ = logger.Info("info")
+	_ = logger.Error("error")
+	if count != 1 {
+		t.Errorf("Expected hook to run once, ran %d times", count)
+	}
+}
+
+// TestHookMutatesMessage verifies that message and level changes are reflected in the output.
+func TestHookMutatesMessage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithHook(HookFunc(func(e *Entry) bool {
+		e.Level = WarnIssuer
+		e.Message = "rewritten"
+		return true
+	})))
+	_ = logger.Info("original")
+	output := buf.String()
+	if !strings.Contains(output, "warn:") || !strings.Contains(output, "rewritten") {
+		t.Errorf("Expected rewritten warning, got: %s", output)
+	}
+}
+
+// TestFieldQuoting verifies that ambiguous field values are quoted.
+func TestFieldQuoting(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	_ = logger.Info("fields", F("empty", ""), F("spaced", "a b"), F("plain", "ok"))
+	if !strings.HasSuffix(buf.String(), `fields empty="" spaced="a b" plain=ok`+"\n") {
+		t.Errorf("Unexpected field rendering: %q", buf.String())
+	}
+}
+
+// TestFieldKeyQuoting verifies that ambiguous field keys are quoted, including the keys
+// derived from them for error chains.
+func TestFieldKeyQuoting(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	_ = logger.Info("keys", F("bad key", "x"), F("a=b", 1), F(`q"`, 2), F("", 3), NamedErr("my err", errors.New("boom")))
+	want := `keys "bad key"=x "a=b"=1 "q\""=2 ""=3 "my err"=boom "my err.chain"=*errors.errorString` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected %q, got: %q", want, buf.String())
+	}
+}
+
+// TestFieldsWithoutMessage verifies that fields logged without a message follow the label
+// after a single space.
+func TestFieldsWithoutMessage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerStyle(CallerNone))
+	_ = logger.Info(F("a", 1))
+	if !strings.HasSuffix(buf.String(), ": test-service:info: a=1\n") {
+		t.Errorf("Expected a single space before the fields, got: %q", buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package loggy
+
+// LogFn logs the message components returned by fn at the given severity. fn is only
+// invoked if the entry will actually be written, so it may safely build expensive
+// payloads. As with Log, the first returned element may be a Caller to adjust the
+// reported source location.
+//
+// Example:
+//
+//	logger.LogFn(DebugIssuer, func() []interface{} {
+//		return []interface{}{"request body: ", dump(req)}
+//	})
+func (l *Logger) LogFn(level Severity, fn func() []interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(level) {
+		return nil
+	}
+	return l.Log(level, fn()...)
+}
+
+// DebugFn logs the message returned by fn at the debug level.
+// fn is only invoked if debug entries are enabled.
+//
+// Example:
+//
+//	logger.DebugFn(func() string { return "request body: " + dump(req) })
+func (l *Logger) DebugFn(fn func() string) error {
+	l.testHelper().Helper()
+	if !l.admit(DebugIssuer) {
+		return nil
+	}
+	return l.Log(DebugIssuer, fn())
+}
+
+// InfoFn logs the message returned by fn at the info level.
+// fn is only invoked if info entries are enabled.
+func (l *Logger) InfoFn(fn func() string) error {
+	l.testHelper().Helper()
+	if !l.admit(InfoIssuer) {
+		return nil
+	}
+	return l.Log(InfoIssuer, fn())
+}
+
+// WarnFn logs the message returned by fn at the warning level.
+// fn is only invoked if warning entries are enabled.
+func (l *Logger) WarnFn(fn func() string) error {
+	l.testHelper().Helper()
+	if !l.admit(WarnIssuer) {
+		return nil
+	}
+	return l.Log(WarnIssuer, fn())
+}
+
+// ErrorFn logs the message returned by fn at the error level.
+// fn is only invoked if error entries are enabled.
+func (l *Logger) ErrorFn(fn func() string) error {
+	l.testHelper().Helper()
+	if !l.admit(ErrorIssuer) {
+		return nil
+	}
+	return l.Log(ErrorIssuer, fn())
+}
@@ -0,0 +1,58 @@
+package loggy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestEnabled verifies Enabled against the configured level.
+func TestEnabled(t *testing.T) {
+	logger := New(": test-service:", new(bytes.Buffer), WarnIssuer)
+	if logger.Enabled(InfoIssuer) {
+		t.Error("Expected info to be disabled at WarnIssuer")
+	}
+	if !logger.Enabled(ErrorIssuer) {
+		t.Error("Expected error to be enabled at WarnIssuer")
+	}
+	if logger.Enabled(DisableIssuer) {
+		t.Error("Expected DisableIssuer never to be enabled")
+	}
+}
+
+// TestLazyClosuresOnlyRunWhenEnabled verifies that closures are skipped for filtered levels.
+func TestLazyClosuresOnlyRunWhenEnabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, InfoIssuer)
+	calls := 0
+	_ = logger.DebugFn(func() string { calls++; return "expensive" })
+	_ = logger.LogFn(DebugIssuer, func() []interface{} { calls++; return []interface{}{"expensive"} })
+	if calls != 0 || buf.Len() != 0 {
+		t.Errorf("Expected filtered closures not to run, ran %d times, output: %s", calls, buf.String())
+	}
+	_ = logger.InfoFn(func() string { calls++; return "lazy info" })
+	_ = logger.LogFn(WarnIssuer, func() []interface{} { calls++; return []interface{}{"lazy ", "warn", F("n", 1)} })
+	if calls != 2 {
+		t.Errorf("Expected enabled closures to run once each, ran %d times", calls)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "info: lazy_test.go:") || !strings.Contains(output, "lazy info") {
+		t.Errorf("Expected lazy info entry with caller, got: %s", output)
+	}
+	if !strings.Contains(output, "lazy warn n=1") {
+		t.Errorf("Expected lazy warn entry with field, got: %s", output)
+	}
+}
+
+// TestLogFnCaller verifies that a Caller returned by the closure is honoured.
+func TestLogFnCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerFunction(true))
+	wrapper := func() {
+		_ = logger.LogFn(InfoIssuer, func() []interface{} { return []interface{}{Caller(1), "wrapped"} })
+	}
+	wrapper()
+	if !strings.Contains(buf.String(), " loggy.TestLogFnCaller: wrapped") {
+		t.Errorf("Expected caller to skip the wrapper, got: %s", buf.String())
+	}
+}
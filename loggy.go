@@ -4,17 +4,19 @@
 // Key features:
 //   - Five severity levels (Debug, Info, Warn, Error, Fatal) with custom labels
 //   - Customizable timestamp formatting and timezone configuration
-//   - Caller source location tracking with stack depth control
+//   - Caller source location tracking with stack depth control and configurable rendering
+//   - Optional stack traces attached to entries at or above a configurable severity
 //   - Thread-safe operations through locker interface compatibility
+//   - Structured key/value fields and hooks that can enrich or veto entries
+//   - Redaction of secrets and personal data in messages and fields
+//   - Optional ANSI-coloured console output with terminal auto-detection
 //   - Package-level default logger and configurable instances
 package loggy
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"path/filepath"
-	"runtime"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -22,6 +24,8 @@ import (
 // New creates a new Logger instance configured with the provided parameters and options.
 // The logger's name must be formatted as ": name:" (with a colon, a space, the name, and a colon).
 // The writer parameter must be non-nil, and the minLevel must be a valid severity (below DisableLogger).
+// New is kept for compatibility; NewLogger accepts a plain name and returns errors instead
+// of panicking.
 //
 // Parameters:
 //   - name: a string identifier in the format ": name:" (e.g., ": my-service:").
@@ -39,17 +43,77 @@ func New(name string, writer io.Writer, minLevel Severity, opts ...Option) *Logg
 	if writer == nil || minLevel > DisableIssuer {
 		panic("loggy: invalid writer or severity level")
 	}
+	return newLogger(name, writer, minLevel, opts)
+}
+
+// NewLogger creates a new Logger named name (e.g. "my-service"), writing entries at or
+// above minLevel to w. Unlike New, it takes the name as is and reports invalid arguments
+// as errors rather than panicking: every problem is reported, joined with errors.Join,
+// and each matches one of ErrInvalidName, ErrNilWriter, ErrInvalidLevel or ErrNilOption
+// with errors.Is.
+//
+// Example:
+//
+//	logger, err := NewLogger(cfg.ServiceName, os.Stdout, InfoIssuer, WithUTC(true))
+//	if err != nil {
+//		return err
+//	}
+func NewLogger(name string, w io.Writer, minLevel Severity, opts ...Option) (*Logger, error) {
+	var errs []error
+	if problem := nameProblem(name); problem != "" {
+		errs = append(errs, fmt.Errorf("%w %q: %s", ErrInvalidName, name, problem))
+	}
+	if w == nil {
+		errs = append(errs, ErrNilWriter)
+	}
+	if minLevel > DisableIssuer {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidLevel, minLevel))
+	}
+	for i, opt := range opts {
+		if opt == nil {
+			errs = append(errs, fmt.Errorf("%w: option %d", ErrNilOption, i))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return newLogger(": "+name+":", w, minLevel, opts), nil
+}
+
+// nameProblem describes why name cannot be used as a Logger name, or returns an empty
+// string if it can. It is shared by NewLogger, Named and Config.Validate.
+func nameProblem(name string) string {
+	switch {
+	case name == "":
+		return "must not be empty"
+	case strings.ContainsAny(name, ":\r\n"):
+		return "must not contain ':' or line breaks"
+	}
+	return ""
+}
+
+// newLogger creates a Logger from validated arguments, with name in the ": name:" form.
+func newLogger(name string, writer io.Writer, minLevel Severity, opts []Option) *Logger {
 	l := &Logger{
 		name:          name,
 		writer:        writer,
-		minLevel:      minLevel,
-		timeFormat:    "2006-01-02 15:04:05.000000",
+		timeFormat:    defaultTimeFormat,
 		useUTC:        false,
-		severityNames: []string{"debug:", "info:", "warn:", "error:", "fatal:"},
+		severityNames: defaultSeverityNames(),
+		colorMode:     ColorNever,
+		colorTheme:    DefaultColorTheme,
+		stackLevel:    DisableIssuer,
+		panicLevel:    ErrorIssuer,
+		errorInterval: defaultErrorReportInterval,
 	}
+	l.minLevel.Store(uint32(minLevel))
 	for _, opt := range opts {
-		opt(l)
+		if opt != nil {
+			opt(l)
+		}
 	}
+	l.publish()
+	l.enroll()
 	return l
 }
 
@@ -62,6 +126,7 @@ func New(name string, writer io.Writer, minLevel Severity, opts ...Option) *Logg
 func WithTimeFormat(format string) Option {
 	return func(l *Logger) {
 		l.timeFormat = format
+		l.overrides |= setTimeFormat
 	}
 }
 
@@ -74,6 +139,21 @@ func WithTimeFormat(format string) Option {
 func WithUTC(utc bool) Option {
 	return func(l *Logger) {
 		l.useUTC = utc
+		l.overrides |= setUTC
+	}
+}
+
+// WithLevel returns an Option that sets the minimum severity, overriding the level passed
+// to New or, for sub-loggers created with Named, the level inherited from the parent.
+//
+// Example:
+//
+//	db := logger.Named("db", WithLevel(WarnIssuer))
+func WithLevel(level Severity) Option {
+	return func(l *Logger) {
+		if level <= DisableIssuer {
+			l.minLevel.Store(uint32(level))
+		}
 	}
 }
 
@@ -87,6 +167,7 @@ func WithSeverityNames(names []string) Option {
 	return func(l *Logger) {
 		if len(names) == 5 {
 			l.severityNames = names
+			l.overrides |= setSeverityNames
 		}
 	}
 }
@@ -101,6 +182,7 @@ func (l *Logger) Name() string {
 // If both the current writer and the new writer implement the locker interface but are not the same,
 // the update is rejected (returns false) to avoid locking mismatches. Otherwise, the writer is updated.
 // The function locks the current writer (if possible) during the update to ensure thread safety.
+// When colour is configured with ColorAuto, the terminal detection is repeated for the new writer.
 //
 // Parameters:
 //   - w: the new io.Writer to use as the logging destination.
@@ -112,7 +194,8 @@ func (l *Logger) UpdateWriter(w io.Writer) bool {
 	if w == nil {
 		return false
 	}
-	currentLocker, hasLock := l.writer.(locker)
+	current := l.layout().writer
+	currentLocker, hasLock := current.(locker)
 	newLocker, newHasLock := w.(locker)
 	if hasLock && newHasLock && currentLocker != newLocker {
 		return false
@@ -121,25 +204,140 @@ func (l *Logger) UpdateWriter(w io.Writer) bool {
 		currentLocker.Lock()
 		defer currentLocker.Unlock()
 	}
+	l.mu.Lock()
 	l.writer = w
+	l.publish()
+	l.mu.Unlock()
 	return true
 }
 
+// publish stores a snapshot of the Logger's writer and rendering settings for use by Log,
+// so that they can be changed while other goroutines are logging. On a sub-logger, it
+// marks its layout as overridden so that it is derived again from its parent's.
+// Callers other than New and Named must hold l.mu.
+func (l *Logger) publish() {
+	if l.parent != nil {
+		l.derived.Store(&derivedLayout{})
+		return
+	}
+	l.current.Store(l.snapshot())
+}
+
+// snapshot returns the layout described by the Logger's fields.
+func (l *Logger) snapshot() *layout {
+	return &layout{
+		writer:        l.writer,
+		timeFormat:    l.timeFormat,
+		useUTC:        l.useUTC,
+		severityNames: l.severityNames,
+		colorMode:     l.colorMode,
+		colorTheme:    l.colorTheme,
+		colorize:      shouldColorize(l.colorMode, l.writer),
+		callerStyle:   l.callerStyle,
+		callerFunc:    l.callerFunc,
+	}
+}
+
+// layout returns the snapshot used to render the Logger's entries. A sub-logger without
+// overrides uses its nearest ancestor's layout, so that changes to the ancestor's writer
+// or settings reach it; one with overrides derives its layout from its parent's.
+func (l *Logger) layout() *layout {
+	if l.parent == nil {
+		return l.current.Load()
+	}
+	base := l.parent.layout()
+	d := l.derived.Load()
+	if d == nil {
+		return base
+	}
+	if d.base == base {
+		return d.ly
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if d = l.derived.Load(); d.base == base {
+		return d.ly
+	}
+	// Take the settings the sub-logger overrides from its own fields.
+	ly := *base
+	if l.overrides&setTimeFormat != 0 {
+		ly.timeFormat = l.timeFormat
+	}
+	if l.overrides&setUTC != 0 {
+		ly.useUTC = l.useUTC
+	}
+	if l.overrides&setSeverityNames != 0 {
+		ly.severityNames = l.severityNames
+	}
+	if l.overrides&setColorMode != 0 {
+		ly.colorMode = l.colorMode
+	}
+	if l.overrides&setColorTheme != 0 {
+		ly.colorTheme = l.colorTheme
+	}
+	if l.overrides&setCallerStyle != 0 {
+		ly.callerStyle = l.callerStyle
+	}
+	if l.overrides&setCallerFunc != 0 {
+		ly.callerFunc = l.callerFunc
+	}
+	if l.writer != nil {
+		ly.writer = l.writer
+	}
+	ly.colorize = shouldColorize(ly.colorMode, ly.writer)
+	l.derived.Store(&derivedLayout{base: base, ly: &ly})
+	return &ly
+}
+
+// reconfigure applies rendering options (time format, UTC, severity names, colour and
+// caller settings) to a live Logger. The new settings take effect atomically for
+// subsequent entries. Options affecting other settings must not be passed. On a
+// sub-logger, the settings they set override those of its parent.
+func (l *Logger) reconfigure(opts ...Option) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.publish()
+}
+
 // SetLevel changes the Logger's minimum logging severity level at runtime.
-// Only messages at or above the new level will be logged.
+// Only messages at or above the new level will be logged. It is safe to call
+// concurrently with logging. The change also applies to sub-loggers created with
+// Named that have not set their own level; on a sub-logger, it sets its own level.
 //
 // Parameters:
 //   - level: the new Severity level to set. Must be a valid level (less than or equal to DisableLogger).
 func (l *Logger) SetLevel(level Severity) {
 	if level <= DisableIssuer {
-		l.minLevel = level
+		l.minLevel.Store(uint32(level))
 	}
 }
 
 // GetLevel returns the current minimum logging severity level.
 // This can be used to inspect the current filtering threshold for logging messages.
+// For a sub-logger inheriting its level, it is the level of the nearest ancestor that set one.
 func (l *Logger) GetLevel() Severity {
-	return l.minLevel
+	lv := l.minLevel.Load()
+	for lv == inheritLevel {
+		l = l.parent
+		lv = l.minLevel.Load()
+	}
+	return Severity(lv)
+}
+
+// Enabled reports whether an entry at the given severity would be written, i.e. whether it
+// is at or above the current minimum level. Use it to guard the construction of expensive
+// log payloads.
+//
+// Example:
+//
+//	if logger.Enabled(DebugIssuer) {
+//		logger.Debug("request body: ", dump(req))
+//	}
+func (l *Logger) Enabled(level Severity) bool {
+	return level >= l.GetLevel() && level < DisableIssuer
 }
 
 // Log is the core function that writes log messages to the Logger's writer if the
@@ -155,13 +353,23 @@ func (l *Logger) GetLevel() Severity {
 // Returns:
 //   - An error if there is a failure while writing to the output; otherwise, nil.
 func (l *Logger) Log(level Severity, msg ...interface{}) error {
-	// Do nothing if the message severity is below the minimum level, is disabled, or no message is provided.
-	if level < l.minLevel || level >= DisableIssuer || len(msg) == 0 {
+	l.testHelper().Helper()
+	return l.log(level, nil, msg)
+}
+
+// log implements Log. If stack is non-nil, it is attached to the entry instead of the
+// current call stack, and its first frame is reported as the caller.
+func (l *Logger) log(level Severity, stack []Frame, msg []interface{}) error {
+	l.testHelper().Helper()
+	// Do nothing if the message severity is filtered out or no message is provided.
+	// This check happens before any formatting so that filtered calls cost nothing.
+	if !l.admit(level) || len(msg) == 0 {
 		return nil
 	}
 
+	ly := l.layout()
 	now := time.Now()
-	if l.useUTC {
+	if ly.useUTC {
 		now = now.UTC()
 	}
 
@@ -180,49 +388,141 @@ func (l *Logger) Log(level Severity, msg ...interface{}) error {
 		}
 	}
 
-	// Use strings.Builder to efficiently build the complete log message.
-	var b strings.Builder
-	b.Grow(128) // Pre-allocate an estimated capacity to minimize allocations.
+	// Separate structured fields from the message components.
+	msg, fields := splitFields(msg)
+	e := Entry{
+		Level:   level,
+		Time:    now,
+		Logger:  l.Name(),
+		Message: sprint(msg),
+		Fields:  fields,
+	}
+
+	// Capture caller information (file name and line number) if enabled.
+	// The skip accounts for log and Log.
+	switch {
+	case stack != nil:
+		if len(stack) > 0 && ly.callerStyle != CallerNone {
+			e.Caller = stack[0]
+		}
+		e.Stack = stack
+	default:
+		if ly.callerStyle != CallerNone {
+			if frame, ok := captureCaller(skip + 3); ok {
+				e.Caller = frame
+			}
+		}
+		// Capture the call stack when configured for this severity.
+		if level >= l.stackLevel {
+			e.Stack = captureStack(skip + 3)
+		}
+	}
+
+	// Redact secrets before the entry is exposed to hooks or the writer.
+	if l.redactor != nil {
+		l.redactor.redact(&e)
+	}
+
+	// Give hooks the chance to enrich or veto the entry before it is encoded.
+	if len(l.hooks) > 0 {
+		var keep bool
+		if e, keep = l.fireHooks(e); !keep {
+			l.counters[level].dropped.Add(1)
+			return nil
+		}
+	}
+
+	// Encode the entry into a pooled buffer and write the bytes directly.
+	buf := getBuffer()
+	defer buf.free()
+	ly.format(buf, l.name, &e)
+
+	// Write the log entry to the configured writer with locking if available.
+	if err := l.writeLocked(ly.writer, buf.b); err != nil {
+		l.counters[level].writeErrors.Add(1)
+		return l.writeFailed(err, buf.b)
+	}
+	l.counters[level].emitted.Add(1)
+	return nil
+}
+
+// writeLocked writes b to w, holding w's lock if it implements locker. Like every
+// function between the caller and the writer, it is marked as a test helper.
+func (l *Logger) writeLocked(w io.Writer, b []byte) error {
+	l.testHelper().Helper()
+	if lock, ok := w.(locker); ok {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+	_, err := w.Write(b)
+	return err
+}
 
+// format renders the entry using the text layout:
+//
+//	<timestamp><name><severity> <file:line>: <message> <key=value>...
+//
+// followed by the stack trace, if any, as indented continuation lines.
+func (ly *layout) format(b *buffer, name string, e *Entry) {
 	// Compose the log prefix: timestamp, logger name, and severity label.
-	b.WriteString(now.Format(l.timeFormat))
-	b.WriteString(l.name)
-	b.WriteString(l.severityNames[level])
+	b.WriteTime(e.Time, ly.timeFormat)
+	b.WriteString(name)
+	if ly.colorize {
+		writeColored(b, ly.colorTheme[e.Level], ly.severityNames[e.Level])
+	} else {
+		b.WriteString(ly.severityNames[e.Level])
+	}
 
-	// Append caller information (file name and line number) if available.
-	if _, file, line, ok := runtime.Caller(skip + 2); ok {
+	// Append caller information if available.
+	if e.Caller.File != "" && ly.callerStyle != CallerNone {
 		b.WriteByte(' ')
-		b.WriteString(filepath.Base(file))
-		b.WriteByte(':')
-		b.WriteString(strconv.Itoa(line))
+		if ly.colorize {
+			beginColor(b, callerColor)
+		}
+		writeLocation(b, e.Caller, ly.callerStyle)
+		if ly.callerFunc {
+			b.WriteByte(' ')
+			b.WriteString(e.Caller.ShortFunction())
+		}
+		if ly.colorize {
+			endColor(b, callerColor)
+		}
 		b.WriteByte(':')
 	}
 
-	b.WriteByte(' ')
+	// Write the message followed by its fields, ensuring the line ends with a single newline.
+	// Fields without a message follow the label or caller after a single space.
+	msg := e.Message
+	if n := len(msg); n > 0 && msg[n-1] == '\n' {
+		msg = msg[:n-1]
+	}
+	if msg != "" || len(e.Fields) == 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(msg)
+	writeFields(b, e.Fields)
+	b.WriteByte('\n')
+
+	// Attach the call stack, then the stacks of logged errors, as continuation lines.
+	writeStack(b, e.Stack)
+	writeErrorStacks(b, e.Fields)
+}
 
-	// Combine the log message components.
-	// If there is only one message argument and it is a string, write it directly.
-	if len(msg) == 1 {
+// sprint combines the message components into a single string.
+// If there is only one message argument and it is a string, it is returned directly.
+func sprint(msg []interface{}) string {
+	switch len(msg) {
+	case 0:
+		return ""
+	case 1:
 		if s, ok := msg[0].(string); ok {
-			b.WriteString(s)
-		} else {
-			b.WriteString(fmt.Sprint(msg[0]))
+			return s
 		}
-	} else {
+		return fmt.Sprint(msg[0])
+	default:
 		// For multiple arguments, combine them using fmt.Sprint.
-		b.WriteString(fmt.Sprint(msg...))
-	}
-	// Ensure the message ends with a newline.
-	if b.Len() == 0 || b.String()[b.Len()-1] != '\n' {
-		b.WriteByte('\n')
+		return fmt.Sprint(msg...)
 	}
-	// Write the log entry to the configured writer with locking if available.
-	if lock, ok := l.writer.(locker); ok {
-		lock.Lock()
-		defer lock.Unlock()
-	}
-	_, err := io.WriteString(l.writer, b.String())
-	return err
 }
 
 // Debug logs a debug-level message using the Logger instance.
@@ -233,6 +533,7 @@ func (l *Logger) Log(level Severity, msg ...interface{}) error {
 //	logger.Debug("This is a debug message.")
 //	logger.Debug(Caller(1), "Message from a wrapper function.")
 func (l *Logger) Debug(msg ...interface{}) error {
+	l.testHelper().Helper()
 	return l.Log(DebugIssuer, msg...)
 }
 
@@ -243,42 +544,61 @@ func (l *Logger) Debug(msg ...interface{}) error {
 //
 //	logger.Debugf("Debug value: %v", someValue)
 func (l *Logger) Debugf(format string, args ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(DebugIssuer) {
+		return nil
+	}
 	return l.Log(DebugIssuer, fmt.Sprintf(format, args...))
 }
 
 // Info logs an informational message using the Logger instance.
 // An optional Caller argument may be provided as the first parameter to control the caller depth.
 func (l *Logger) Info(msg ...interface{}) error {
+	l.testHelper().Helper()
 	return l.Log(InfoIssuer, msg...)
 }
 
 // Infof logs a formatted informational message using the Logger instance.
 // It formats the message using the provided format string and arguments.
 func (l *Logger) Infof(format string, args ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(InfoIssuer) {
+		return nil
+	}
 	return l.Log(InfoIssuer, fmt.Sprintf(format, args...))
 }
 
 // Warn logs a warning message using the Logger instance.
 // An optional Caller argument may be provided as the first parameter to control the caller depth.
 func (l *Logger) Warn(msg ...interface{}) error {
+	l.testHelper().Helper()
 	return l.Log(WarnIssuer, msg...)
 }
 
 // Warnf logs a formatted warning message using the Logger instance.
 // It formats the message using the provided format string and arguments.
 func (l *Logger) Warnf(format string, args ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(WarnIssuer) {
+		return nil
+	}
 	return l.Log(WarnIssuer, fmt.Sprintf(format, args...))
 }
 
 // Error logs an error message using the Logger instance.
 // An optional Caller argument may be provided as the first parameter to control the caller depth.
 func (l *Logger) Error(msg ...interface{}) error {
+	l.testHelper().Helper()
 	return l.Log(ErrorIssuer, msg...)
 }
 
 // Errorf logs a formatted error message using the Logger instance.
 // It formats the message using the provided format string and arguments.
 func (l *Logger) Errorf(format string, args ...interface{}) error {
+	l.testHelper().Helper()
+	if !l.admit(ErrorIssuer) {
+		return nil
+	}
 	return l.Log(ErrorIssuer, fmt.Sprintf(format, args...))
 }
 
@@ -287,8 +607,9 @@ func (l *Logger) Errorf(format string, args ...interface{}) error {
 // The panic message consists of the logger name and fatal severity label concatenated with any
 // error string returned during the logging process.
 func (l *Logger) Fatal(msg ...interface{}) error {
+	l.testHelper().Helper()
 	err := l.Log(FatalIssuer, msg...)
-	pm := l.Name() + l.severityNames[FatalIssuer]
+	pm := l.Name() + l.layout().severityNames[FatalIssuer]
 	if err != nil {
 		pm += err.Error()
 	}
@@ -300,8 +621,9 @@ func (l *Logger) Fatal(msg ...interface{}) error {
 // The panic message consists of the logger name and fatal severity label concatenated with any
 // error string returned during the logging process.
 func (l *Logger) Fatalf(format string, args ...interface{}) error {
+	l.testHelper().Helper()
 	err := l.Log(FatalIssuer, fmt.Sprintf(format, args...))
-	pm := l.Name() + l.severityNames[FatalIssuer]
+	pm := l.Name() + l.layout().severityNames[FatalIssuer]
 	if err != nil {
 		pm += err.Error()
 	}
@@ -316,6 +638,9 @@ func Debug(msg ...interface{}) error {
 
 // Debugf logs a formatted debug-level message using the package-level Default logger.
 func Debugf(format string, args ...interface{}) error {
+	if !Default.admit(DebugIssuer) {
+		return nil
+	}
 	return Default.Log(DebugIssuer, fmt.Sprintf(format, args...))
 }
 
@@ -327,6 +652,9 @@ func Info(msg ...interface{}) error {
 
 // Infof logs a formatted informational message using the package-level Default logger.
 func Infof(format string, args ...interface{}) error {
+	if !Default.admit(InfoIssuer) {
+		return nil
+	}
 	return Default.Log(InfoIssuer, fmt.Sprintf(format, args...))
 }
 
@@ -338,6 +666,9 @@ func Warn(msg ...interface{}) error {
 
 // Warnf logs a formatted warning message using the package-level Default logger.
 func Warnf(format string, args ...interface{}) error {
+	if !Default.admit(WarnIssuer) {
+		return nil
+	}
 	return Default.Log(WarnIssuer, fmt.Sprintf(format, args...))
 }
 
@@ -349,6 +680,9 @@ func Error(msg ...interface{}) error {
 
 // Errorf logs a formatted error message using the package-level Default logger.
 func Errorf(format string, args ...interface{}) error {
+	if !Default.admit(ErrorIssuer) {
+		return nil
+	}
 	return Default.Log(ErrorIssuer, fmt.Sprintf(format, args...))
 }
 
@@ -356,7 +690,7 @@ func Errorf(format string, args ...interface{}) error {
 // An optional Caller argument may be provided as the first parameter.
 func Fatal(msg ...interface{}) error {
 	err := Default.Log(FatalIssuer, msg...)
-	pm := Default.Name() + Default.severityNames[FatalIssuer]
+	pm := Default.Name() + Default.layout().severityNames[FatalIssuer]
 	if err != nil {
 		pm += err.Error()
 	}
@@ -366,7 +700,7 @@ func Fatal(msg ...interface{}) error {
 // Fatalf logs a formatted fatal message using the package-level Default logger and then triggers a panic.
 func Fatalf(format string, args ...interface{}) error {
 	err := Default.Log(FatalIssuer, fmt.Sprintf(format, args...))
-	pm := Default.Name() + Default.severityNames[FatalIssuer]
+	pm := Default.Name() + Default.layout().severityNames[FatalIssuer]
 	if err != nil {
 		pm += err.Error()
 	}
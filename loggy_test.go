@@ -2,6 +2,8 @@ package loggy
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -62,6 +64,58 @@ func TestNewInvalidWriter(t *testing.T) {
 	_ = New(": test-service:", nil, DebugIssuer)
 }
 
+// TestNewLogger verifies that NewLogger accepts a plain name and behaves like New.
+func TestNewLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger("plain-service", buf, InfoIssuer, WithCallerStyle(CallerNone))
+	if err != nil {
+		t.Fatalf("Unexpected error from NewLogger: %v", err)
+	}
+	if logger.Name() != "plain-service" {
+		t.Errorf("Expected logger name 'plain-service', got '%s'", logger.Name())
+	}
+	logger.Debug("filtered")
+	logger.Info("hello")
+	if !strings.HasSuffix(buf.String(), ": plain-service:info: hello\n") {
+		t.Errorf("Expected the usual layout, got: %q", buf.String())
+	}
+}
+
+// TestNewLoggerInvalid verifies that NewLogger reports every invalid argument as a typed error.
+func TestNewLoggerInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		w     *bytes.Buffer
+		level Severity
+		opts  []Option
+		want  []error
+	}{
+		{"", new(bytes.Buffer), DebugIssuer, nil, []error{ErrInvalidName}},
+		{": legacy:", new(bytes.Buffer), DebugIssuer, nil, []error{ErrInvalidName}},
+		{"multi\nline", new(bytes.Buffer), DebugIssuer, nil, []error{ErrInvalidName}},
+		{"svc", nil, DebugIssuer, nil, []error{ErrNilWriter}},
+		{"svc", new(bytes.Buffer), DisableIssuer + 1, nil, []error{ErrInvalidLevel}},
+		{"svc", new(bytes.Buffer), DebugIssuer, []Option{WithUTC(true), nil}, []error{ErrNilOption}},
+		{"", nil, DisableIssuer + 1, nil, []error{ErrInvalidName, ErrNilWriter, ErrInvalidLevel}},
+	}
+	for _, tt := range tests {
+		var w io.Writer
+		if tt.w != nil {
+			w = tt.w
+		}
+		logger, err := NewLogger(tt.name, w, tt.level, tt.opts...)
+		if logger != nil || err == nil {
+			t.Errorf("%q: expected an error and no Logger, got %v, %v", tt.name, logger, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !errors.Is(err, want) {
+				t.Errorf("%q: expected error matching %v, got: %v", tt.name, want, err)
+			}
+		}
+	}
+}
+
 // TestLogSeverityFiltering ensures that messages below the minimum level are not logged.
 func TestLogSeverityFiltering(t *testing.T) {
 	buf := new(bytes.Buffer)
@@ -238,9 +292,9 @@ func TestPackageLevelFunctions(t *testing.T) {
 	// Save the original writer so we can restore it later.
 	origWriter := Default.writer
 	defer func() {
-		Default.writer = origWriter
+		Default.UpdateWriter(origWriter)
 	}()
-	Default.writer = buf
+	Default.UpdateWriter(buf)
 
 	// Test Info function.
 	Info("package level info")
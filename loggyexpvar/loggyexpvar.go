@@ -0,0 +1,37 @@
+// Package loggyexpvar publishes the loggy entry counters as the "loggy" expvar, served by
+// expvar's /debug/vars handler. It is imported for its side effect, so that programs
+// that do not want expvar's handler on http.DefaultServeMux are not affected by loggy:
+//
+//	import _ "github.com/sivaosorg/loggy/loggyexpvar"
+//
+// The variable maps each Logger name to its counters by severity label, as returned by
+// loggy.Metrics:
+//
+//	{"loggy": {"my-service": {"error": {"emitted": 12, "filtered": 0, ...}, ...}}}
+package loggyexpvar
+
+import (
+	"expvar"
+
+	"github.com/sivaosorg/loggy"
+)
+
+// Publish the counters, unless another copy of the package already did.
+func init() {
+	if expvar.Get("loggy") == nil {
+		expvar.Publish("loggy", expvar.Func(counters))
+	}
+}
+
+// counters returns the value of the "loggy" expvar.
+func counters() interface{} {
+	out := make(map[string]map[string]loggy.LevelStats)
+	for name, stats := range loggy.Metrics() {
+		levels := make(map[string]loggy.LevelStats, len(stats))
+		for lv, s := range stats {
+			levels[loggy.Severity(lv).String()] = s
+		}
+		out[name] = levels
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package loggyexpvar
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/sivaosorg/loggy"
+)
+
+// TestPublished verifies that the counters are published through expvar.
+func TestPublished(t *testing.T) {
+	logger := loggy.New(": expvar-test:", new(bytes.Buffer), loggy.DebugIssuer)
+	logger.Warn("counted")
+	v := expvar.Get("loggy")
+	if v == nil {
+		t.Fatal("Expected the loggy expvar to be published")
+	}
+	var got map[string]map[string]loggy.LevelStats
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("Unexpected expvar JSON: %v", err)
+	}
+	if got["expvar-test"]["warn"].Emitted != 1 {
+		t.Errorf("Unexpected expvar value: %+v", got["expvar-test"])
+	}
+}
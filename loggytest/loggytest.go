@@ -0,0 +1,184 @@
+// Package loggytest provides helpers for capturing and asserting on loggy output in tests.
+//
+// A Recorder is both a loggy.Hook, which stores every entry with its level, logger name,
+// caller, message and fields, and an io.Writer, which stores the rendered text for
+// golden-output comparisons. FreezeClock and FreezeCaller make that text deterministic.
+//
+// Example:
+//
+//	logger, rec := loggytest.New(loggytest.FreezeClock(time.Unix(0, 0)))
+//	logger.Info("user created", loggy.F("id", 42))
+//	loggytest.AssertLogged(t, rec, loggytest.Level(loggy.InfoIssuer), loggytest.Field("id", 42))
+package loggytest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/loggy"
+)
+
+// Recorder captures log entries and rendered output in memory.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []loggy.Entry
+	output  strings.Builder
+}
+
+// Matcher reports whether a recorded entry satisfies a condition.
+type Matcher func(e loggy.Entry) bool
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// New returns a logger named ": test:" at DebugIssuer whose entries and output are
+// captured by the returned Recorder. The given options are applied before the recorder
+// is attached, so hooks among them (such as FreezeClock) are reflected in the recording.
+// The logger is kept out of the global registry, like those of loggy.NewTestLogger.
+func New(opts ...loggy.Option) (*loggy.Logger, *Recorder) {
+	r := NewRecorder()
+	opts = append(append([]loggy.Option{loggy.WithoutRegistration()}, opts...), loggy.WithHook(r))
+	return loggy.New(": test:", r, loggy.DebugIssuer, opts...), r
+}
+
+// Fire records a copy of the entry. It implements loggy.Hook and never vetoes entries.
+func (r *Recorder) Fire(e *loggy.Entry) bool {
+	c := *e
+	c.Fields = append([]loggy.Field(nil), e.Fields...)
+	c.Stack = append([]loggy.Frame(nil), e.Stack...)
+	r.mu.Lock()
+	r.entries = append(r.entries, c)
+	r.mu.Unlock()
+	return true
+}
+
+// Write records rendered log output. It implements io.Writer.
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.output.Write(p)
+}
+
+// Entries returns a copy of the recorded entries in the order they were logged.
+func (r *Recorder) Entries() []loggy.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]loggy.Entry(nil), r.entries...)
+}
+
+// Output returns the rendered text written so far.
+func (r *Recorder) Output() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.output.String()
+}
+
+// Filter returns the recorded entries satisfying all matchers.
+func (r *Recorder) Filter(matchers ...Matcher) []loggy.Entry {
+	var out []loggy.Entry
+	for _, e := range r.Entries() {
+		if matchAll(e, matchers) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Reset discards all recorded entries and output.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+	r.output.Reset()
+}
+
+// FreezeClock returns an Option that stamps every entry with the given time,
+// making rendered timestamps stable across runs.
+func FreezeClock(t time.Time) loggy.Option {
+	return loggy.WithHook(loggy.HookFunc(func(e *loggy.Entry) bool {
+		e.Time = t
+		return true
+	}))
+}
+
+// FreezeCaller returns an Option that replaces the caller of every entry with the
+// given frame, making rendered locations stable when test files are edited.
+func FreezeCaller(f loggy.Frame) loggy.Option {
+	return loggy.WithHook(loggy.HookFunc(func(e *loggy.Entry) bool {
+		e.Caller = f
+		return true
+	}))
+}
+
+// Level matches entries logged at the given severity.
+func Level(level loggy.Severity) Matcher {
+	return func(e loggy.Entry) bool { return e.Level == level }
+}
+
+// Logger matches entries produced by the logger with the given name.
+func Logger(name string) Matcher {
+	return func(e loggy.Entry) bool { return e.Logger == name }
+}
+
+// Message matches entries whose message contains substr.
+func Message(substr string) Matcher {
+	return func(e loggy.Entry) bool { return strings.Contains(e.Message, substr) }
+}
+
+// Field matches entries carrying a field with the given key and a value whose
+// textual form equals that of value.
+func Field(key string, value interface{}) Matcher {
+	want := fmt.Sprint(value)
+	return func(e loggy.Entry) bool {
+		for _, f := range e.Fields {
+			if f.Key == key && fmt.Sprint(f.Value) == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AssertLogged reports a test error unless at least one recorded entry satisfies all matchers.
+func AssertLogged(t testing.TB, r *Recorder, matchers ...Matcher) bool {
+	t.Helper()
+	if len(r.Filter(matchers...)) == 0 {
+		t.Errorf("loggytest: expected a matching entry, none found in:\n%s", r.Output())
+		return false
+	}
+	return true
+}
+
+// AssertNotLogged reports a test error if any recorded entry satisfies all matchers.
+func AssertNotLogged(t testing.TB, r *Recorder, matchers ...Matcher) bool {
+	t.Helper()
+	if found := r.Filter(matchers...); len(found) > 0 {
+		t.Errorf("loggytest: expected no matching entry, found %d in:\n%s", len(found), r.Output())
+		return false
+	}
+	return true
+}
+
+// RequireCount stops the test unless exactly n recorded entries satisfy all matchers.
+func RequireCount(t testing.TB, r *Recorder, n int, matchers ...Matcher) {
+	t.Helper()
+	if got := len(r.Filter(matchers...)); got != n {
+		t.Fatalf("loggytest: expected %d matching entries, found %d in:\n%s", n, got, r.Output())
+	}
+}
+
+// matchAll reports whether e satisfies every matcher.
+func matchAll(e loggy.Entry, matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m(e) {
+			return false
+		}
+	}
+	return true
+}
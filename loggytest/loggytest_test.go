@@ -0,0 +1,79 @@
+package loggytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/loggy"
+)
+
+// TestRecorderCapturesEntries verifies that entries are recorded with their structured data.
+func TestRecorderCapturesEntries(t *testing.T) {
+	logger, rec := New()
+	_ = logger.Info("user created", loggy.F("id", 42))
+	_ = logger.Warn("quota low")
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Level != loggy.InfoIssuer || e.Logger != "test" || e.Message != "user created" {
+		t.Errorf("Unexpected entry: %+v", e)
+	}
+	if e.Caller.Line == 0 {
+		t.Errorf("Expected caller to be recorded, got: %+v", e.Caller)
+	}
+	AssertLogged(t, rec, Level(loggy.InfoIssuer), Field("id", 42))
+	AssertLogged(t, rec, Logger("test"), Message("quota"))
+	AssertNotLogged(t, rec, Level(loggy.ErrorIssuer))
+	RequireCount(t, rec, 2)
+	RequireCount(t, rec, 1, Level(loggy.WarnIssuer))
+	if _, ok := loggy.Lookup("test"); ok {
+		t.Error("Expected the logger to be kept out of the registry")
+	}
+}
+
+// TestAssertionsReportFailures verifies that the helpers fail the test when unsatisfied.
+func TestAssertionsReportFailures(t *testing.T) {
+	logger, rec := New()
+	_ = logger.Info("hello")
+
+	ft := &fakeTB{TB: t}
+	if AssertLogged(ft, rec, Level(loggy.ErrorIssuer)) || !ft.failed {
+		t.Error("Expected AssertLogged to fail for a missing entry")
+	}
+	ft = &fakeTB{TB: t}
+	if AssertNotLogged(ft, rec, Message("hello")) || !ft.failed {
+		t.Error("Expected AssertNotLogged to fail for a present entry")
+	}
+}
+
+// TestGoldenOutput verifies that a frozen clock and caller make the rendered output stable.
+func TestGoldenOutput(t *testing.T) {
+	logger, rec := New(
+		FreezeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		FreezeCaller(loggy.Frame{Function: "app.main", File: "/src/app/main.go", Line: 10}),
+	)
+	_ = logger.Info("started", loggy.F("port", 8080))
+	want := "2024-01-02 03:04:05.000000: test:info: main.go:10: started port=8080\n"
+	if got := rec.Output(); got != want {
+		t.Errorf("Unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+	rec.Reset()
+	if len(rec.Entries()) != 0 || rec.Output() != "" {
+		t.Error("Expected Reset to clear the recorder")
+	}
+}
+
+// fakeTB records failures instead of reporting them to the real test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.failed = true
+}
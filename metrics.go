@@ -0,0 +1,135 @@
+package loggy
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// admit reports whether an entry at the given severity is enabled, counting it as
+// filtered otherwise. Logging methods use it in place of Enabled.
+func (l *Logger) admit(level Severity) bool {
+	if l.Enabled(level) {
+		return true
+	}
+	if level < DisableIssuer {
+		l.counters[level].filtered.Add(1)
+	}
+	return false
+}
+
+// Stats returns the counters of entries logged at the given severity by all Loggers
+// sharing l's name.
+//
+// Example:
+//
+//	if s := logger.Stats(ErrorIssuer); s.WriteErrors > 0 {
+//		alert("log writes are failing")
+//	}
+func (l *Logger) Stats(level Severity) LevelStats {
+	if level >= DisableIssuer {
+		return LevelStats{}
+	}
+	return l.counters[level].snapshot()
+}
+
+// Metrics returns the entry counters of every Logger name, indexed by severity from
+// DebugIssuer to FatalIssuer. The same counters are served in the Prometheus text format
+// by MetricsHandler, and published as the "loggy" expvar by importing package loggyexpvar.
+func Metrics() map[string][DisableIssuer]LevelStats {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	out := make(map[string][DisableIssuer]LevelStats, len(metrics.byName))
+	for name, c := range metrics.byName {
+		var stats [DisableIssuer]LevelStats
+		for lv := range c {
+			stats[lv] = c[lv].snapshot()
+		}
+		out[name] = stats
+	}
+	return out
+}
+
+// MetricsHandler returns an http.Handler serving the entry counters in the Prometheus
+// text exposition format, without depending on the Prometheus client library:
+//
+//	loggy_entries_emitted_total{logger="svc",level="error"} 12
+//	loggy_entries_filtered_total{logger="svc",level="debug"} 4096
+//	loggy_entries_dropped_total{logger="svc",level="info"} 3
+//	loggy_write_errors_total{logger="svc",level="error"} 0
+//
+// Example:
+//
+//	mux.Handle("/metrics", MetricsHandler())
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write([]byte(renderPrometheus(Metrics())))
+	})
+}
+
+// renderPrometheus renders the counters in the Prometheus text exposition format,
+// sorted by logger name and severity so that the output is stable.
+func renderPrometheus(m map[string][DisableIssuer]LevelStats) string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, metric := range []struct {
+		name, help string
+		value      func(LevelStats) uint64
+	}{
+		{"loggy_entries_emitted_total", "Log entries written successfully.", func(s LevelStats) uint64 { return s.Emitted }},
+		{"loggy_entries_filtered_total", "Log entries discarded for being below the minimum severity.", func(s LevelStats) uint64 { return s.Filtered }},
+		{"loggy_entries_dropped_total", "Log entries vetoed by a hook.", func(s LevelStats) uint64 { return s.Dropped }},
+		{"loggy_write_errors_total", "Log entries whose write failed.", func(s LevelStats) uint64 { return s.WriteErrors }},
+	} {
+		b.WriteString("# HELP " + metric.name + " " + metric.help + "\n")
+		b.WriteString("# TYPE " + metric.name + " counter\n")
+		for _, name := range names {
+			stats := m[name]
+			for lv := range stats {
+				b.WriteString(metric.name)
+				b.WriteString(`{logger="`)
+				b.WriteString(escapeLabel(name))
+				b.WriteString(`",level="`)
+				b.WriteString(Severity(lv).String())
+				b.WriteString(`"} `)
+				b.WriteString(strconv.FormatUint(metric.value(stats[lv]), 10))
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.String()
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// forName returns the counters shared by Loggers with the given name, creating them if needed.
+func (r *metricsRegistry) forName(name string) *loggerCounters {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.byName[name]
+	if !ok {
+		c = new(loggerCounters)
+		r.byName[name] = c
+	}
+	return c
+}
+
+// snapshot returns the current values of the counters.
+func (c *levelCounters) snapshot() LevelStats {
+	return LevelStats{
+		Emitted:     c.emitted.Load(),
+		Filtered:    c.filtered.Load(),
+		Dropped:     c.dropped.Load(),
+		WriteErrors: c.writeErrors.Load(),
+	}
+}
@@ -0,0 +1,76 @@
+package loggy
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// failingWriter is an io.Writer whose writes always fail.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// TestStats verifies the emitted, filtered, dropped and write error counters.
+func TestStats(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": stats-test:", buf, InfoIssuer, WithHook(HookFunc(func(e *Entry) bool {
+		return e.Message != "vetoed"
+	})))
+	logger.Debug("filtered")
+	logger.Debugf("filtered %d", 2)
+	logger.Info("emitted")
+	logger.Info("vetoed")
+	failing := New(": stats-test:", failingWriter{}, InfoIssuer)
+	if err := failing.Error("lost"); err == nil {
+		t.Error("Expected the write error to be returned")
+	}
+
+	if s := logger.Stats(DebugIssuer); s != (LevelStats{Filtered: 2}) {
+		t.Errorf("Unexpected debug stats: %+v", s)
+	}
+	if s := logger.Stats(InfoIssuer); s != (LevelStats{Emitted: 1, Dropped: 1}) {
+		t.Errorf("Unexpected info stats: %+v", s)
+	}
+	if s := logger.Stats(ErrorIssuer); s != (LevelStats{WriteErrors: 1}) {
+		t.Errorf("Expected counters to be shared by name, got: %+v", s)
+	}
+	if s := Metrics()["stats-test"]; s[InfoIssuer].Emitted != 1 {
+		t.Errorf("Unexpected Metrics entry: %+v", s)
+	}
+}
+
+// TestMetricsHandler verifies the Prometheus text exposition.
+func TestMetricsHandler(t *testing.T) {
+	logger := New(`: prom"test:`, new(bytes.Buffer), WarnIssuer)
+	logger.Info("filtered")
+	logger.Error("emitted")
+
+	rec := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected Content-Type: %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE loggy_entries_emitted_total counter\n",
+		`loggy_entries_emitted_total{logger="prom\"test",level="error"} 1` + "\n",
+		`loggy_entries_filtered_total{logger="prom\"test",level="info"} 1` + "\n",
+		`loggy_write_errors_total{logger="prom\"test",level="fatal"} 0` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected exposition to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+// TestNoDefaultMuxSideEffect verifies that importing loggy registers no handler on
+// http.DefaultServeMux, such as expvar's /debug/vars.
+func TestNoDefaultMuxSideEffect(t *testing.T) {
+	if _, pattern := http.DefaultServeMux.Handler(httptest.NewRequest(http.MethodGet, "/debug/vars", nil)); pattern != "" {
+		t.Errorf("Expected no handler on the default mux, got: %q", pattern)
+	}
+}
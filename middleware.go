@@ -0,0 +1,251 @@
+package loggy
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithAccessLogFormat returns a MiddlewareOption that selects the layout of access log
+// entries. The default is AccessLogStructured.
+func WithAccessLogFormat(format AccessLogFormat) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if format <= AccessLogCombined {
+			c.format = format
+		}
+	}
+}
+
+// WithRequestIDHeader returns a MiddlewareOption that sets the header used to read and
+// propagate request IDs. The default is DefaultRequestIDHeader.
+func WithRequestIDHeader(name string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if name != "" {
+			c.requestIDHeader = http.CanonicalHeaderKey(name)
+		}
+	}
+}
+
+// HTTPMiddleware returns a middleware that logs one entry per request through l, recording
+// the method, path, status, response size, duration, remote address and user agent.
+// The severity follows the status class: ErrorIssuer for 5xx, WarnIssuer for 4xx and
+// InfoIssuer otherwise.
+//
+// The request ID is taken from the request header (X-Request-ID by default) or generated
+// if absent or invalid: IDs longer than 128 bytes or containing characters other than
+// printable ASCII, such as spaces or control characters, are replaced. It is echoed in the response header and stored in the request context, where
+// RequestIDFromContext retrieves it for downstream handlers and outgoing calls. Likewise,
+// the W3C trace context of the request (see TraceFromRequest) is continued with a new span,
+// or a new trace is started, and stored for TraceFromContext. The structured layout
+// includes the registered context values, such as request_id, trace_id and span_id.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	http.ListenAndServe(":8080", HTTPMiddleware(logger, WithAccessLogFormat(AccessLogCombined))(mux))
+func HTTPMiddleware(l *Logger, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	cfg := middlewareConfig{requestIDHeader: DefaultRequestIDHeader}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			id := r.Header.Get(cfg.requestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(cfg.requestIDHeader, id)
+			ctx := ContextWithRequestID(r.Context(), id)
+			if tc, ok := TraceFromRequest(r); ok {
+				ctx = ContextWithTrace(ctx, tc.NewSpan())
+			} else {
+				ctx = ContextWithTrace(ctx, NewTraceContext())
+			}
+			r = r.WithContext(ctx)
+
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+
+			level := statusLevel(rec.status)
+			if !l.admit(level) {
+				return
+			}
+			switch cfg.format {
+			case AccessLogCommon, AccessLogCombined:
+				_ = l.log(level, noCaller, []interface{}{formatCLF(r, rec, start, cfg.format == AccessLogCombined)})
+			default:
+				_ = l.log(level, noCaller, contextValues(r.Context(), []interface{}{r.Method, " ", r.URL.RequestURI(),
+					F("status", rec.status),
+					F("bytes", rec.bytes),
+					F("duration", time.Since(start)),
+					F("remote", r.RemoteAddr),
+					F("user_agent", r.UserAgent())}))
+			}
+		})
+	}
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// statusLevel returns the severity used to log a response with the given status code.
+func statusLevel(status int) Severity {
+	switch {
+	case status >= 500:
+		return ErrorIssuer
+	case status >= 400:
+		return WarnIssuer
+	default:
+		return InfoIssuer
+	}
+}
+
+// formatCLF renders a request in the Apache Common Log Format, or the Combined Log Format
+// if combined is true.
+func formatCLF(r *http.Request, rec *responseRecorder, start time.Time, combined bool) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	user := "-"
+	if u, _, ok := r.BasicAuth(); ok && u != "" {
+		user = u
+	}
+	size := "-"
+	if rec.bytes > 0 {
+		size = strconv.FormatInt(rec.bytes, 10)
+	}
+	var b strings.Builder
+	b.WriteString(clfField(host))
+	b.WriteString(" - ")
+	b.WriteString(clfField(user))
+	b.WriteString(" [")
+	b.WriteString(start.Format("02/Jan/2006:15:04:05 -0700"))
+	b.WriteString("] \"")
+	b.WriteString(clfQuoted(r.Method + " " + r.URL.RequestURI() + " " + r.Proto))
+	b.WriteString("\" ")
+	b.WriteString(strconv.Itoa(rec.status))
+	b.WriteByte(' ')
+	b.WriteString(size)
+	if combined {
+		b.WriteString(" \"")
+		b.WriteString(clfQuoted(orDash(r.Referer())))
+		b.WriteString("\" \"")
+		b.WriteString(clfQuoted(orDash(r.UserAgent())))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// clfField returns s, or "-" if it is empty, with whitespace replaced so it stays one token.
+func clfField(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// clfQuoted escapes quotes and control characters in a value written between double quotes.
+func clfQuoted(s string) string {
+	q := strconv.Quote(s)
+	return q[1 : len(q)-1]
+}
+
+// orDash returns s, or "-" if it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// validRequestID reports whether a request ID received from a client may be logged, echoed
+// and propagated: it must be non-empty, at most maxRequestIDLength bytes long and made of
+// printable ASCII characters other than space.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit identifier in hexadecimal.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// WriteHeader records the status code before delegating.
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written before delegating.
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher if the underlying ResponseWriter does.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker if the underlying ResponseWriter does, so that
+// protocols such as WebSocket keep working behind the middleware.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := r.ResponseWriter.(http.Hijacker); ok {
+		if r.status == 0 {
+			r.status = http.StatusSwitchingProtocols
+		}
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("loggy: underlying ResponseWriter does not implement http.Hijacker")
+}
+
+// Unwrap returns the underlying ResponseWriter for use by http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
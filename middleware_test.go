@@ -0,0 +1,145 @@
+package loggy
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestHTTPMiddlewareStructured verifies the default layout and the severity chosen by status class.
+func TestHTTPMiddlewareStructured(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	h := HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte("hello"))
+		}
+	}))
+
+	tests := []struct {
+		path, label, status string
+	}{
+		{"/ok?x=1", "info:", "status=200 bytes=5"},
+		{"/missing", "warn:", "status=404"},
+		{"/broken", "error:", "status=500 bytes=0"},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("User-Agent", "probe/1.0")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		output := buf.String()
+		if !strings.Contains(output, tt.label) {
+			t.Errorf("%s: expected label %q, got: %s", tt.path, tt.label, output)
+		}
+		if !strings.Contains(output, tt.label+" GET "+tt.path+" "+tt.status) {
+			t.Errorf("%s: expected request line without caller and %q, got: %s", tt.path, tt.status, output)
+		}
+		if !strings.Contains(output, "remote=192.0.2.1:1234 user_agent=probe/1.0 request_id=") {
+			t.Errorf("%s: expected remote, user agent and request ID fields, got: %s", tt.path, output)
+		}
+	}
+}
+
+// TestHTTPMiddlewareRequestID verifies that a request ID is propagated or generated.
+func TestHTTPMiddlewareRequestID(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	var seen string
+	h := HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if seen != "abc-123" || rec.Header().Get("X-Request-ID") != "abc-123" {
+		t.Errorf("Expected incoming request ID to be propagated, got context %q, header %q", seen, rec.Header().Get("X-Request-ID"))
+	}
+	if !strings.Contains(buf.String(), "request_id=abc-123") {
+		t.Errorf("Expected request ID field, got: %s", buf.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(seen) || rec.Header().Get("X-Request-ID") != seen {
+		t.Errorf("Expected a generated request ID echoed in the response, got context %q, header %q", seen, rec.Header().Get("X-Request-ID"))
+	}
+
+	for _, bad := range []string{strings.Repeat("a", 129), "abc 123", "abc\x1b[31m", "id\u00e9"} {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", bad)
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(seen) || rec.Header().Get("X-Request-ID") != seen {
+			t.Errorf("Expected %q to be replaced by a generated request ID, got context %q, header %q", bad, seen, rec.Header().Get("X-Request-ID"))
+		}
+	}
+
+	h = HTTPMiddleware(logger, WithRequestIDHeader("x-correlation-id"))(h)
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Correlation-Id", "corr-1")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Header().Get("X-Correlation-Id") != "corr-1" {
+		t.Errorf("Expected custom header to be echoed, got: %q", rec.Header().Get("X-Correlation-Id"))
+	}
+}
+
+// TestHTTPMiddlewareCLF verifies the Common and Combined Log Formats.
+func TestHTTPMiddlewareCLF(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	})
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/items?id=7", nil)
+		req.SetBasicAuth("alice", "secret")
+		req.Header.Set("Referer", "http://example.com/")
+		req.Header.Set("User-Agent", `probe "quoted"`)
+		return req
+	}
+	common := regexp.MustCompile(`: 192\.0\.2\.1 - alice \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "POST /items\?id=7 HTTP/1\.1" 201 7`)
+
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	HTTPMiddleware(logger, WithAccessLogFormat(AccessLogCommon))(handler).ServeHTTP(httptest.NewRecorder(), newRequest())
+	if !common.MatchString(buf.String()) || !strings.HasSuffix(buf.String(), " 201 7\n") {
+		t.Errorf("Expected Common Log Format, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	HTTPMiddleware(logger, WithAccessLogFormat(AccessLogCombined))(handler).ServeHTTP(httptest.NewRecorder(), newRequest())
+	if !common.MatchString(buf.String()) || !strings.HasSuffix(buf.String(), ` 201 7 "http://example.com/" "probe \"quoted\""`+"\n") {
+		t.Errorf("Expected Combined Log Format, got: %s", buf.String())
+	}
+}
+
+// TestHTTPMiddlewareFiltered verifies that nothing is written below the minimum severity
+// and that the ResponseWriter remains usable through http.ResponseController.
+func TestHTTPMiddlewareFiltered(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, WarnIssuer)
+	var flushErr error
+	h := HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		flushErr = http.NewResponseController(w).Flush()
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if buf.Len() != 0 {
+		t.Errorf("Expected successful requests to be filtered at WarnIssuer, got: %s", buf.String())
+	}
+	if flushErr != nil || !rec.Flushed {
+		t.Errorf("Expected the response to be flushed, got error: %v", flushErr)
+	}
+}
@@ -0,0 +1,72 @@
+package loggy
+
+import "slices"
+
+// Named returns a sub-logger whose name is the Logger's name followed by a dot and name,
+// e.g. "svc.db" for Named("db") on the "svc" Logger. The sub-logger starts with a copy of
+// the parent's writer and settings (time format, severity names, colours, caller style,
+// hooks, redaction, stack traces, panic and write error handling), which opts may
+// override, and inherits its level: SetLevel on an ancestor applies to it until it sets
+// its own level, either through SetLevel or an Option. Call InheritLevel to defer to the
+// parent again.
+//
+// Like Loggers created with New, the sub-logger is added to the global registry, unless
+// its parent was kept out of it with WithoutRegistration.
+//
+// Named panics if name is empty or contains ':' or a line break, like NewLogger.
+//
+// Example:
+//
+//	svc := New(": svc:", os.Stdout, InfoIssuer)
+//	db := svc.Named("db")  // logs as "svc.db"
+//	svc.SetLevel(DebugIssuer) // db now logs debug entries too
+func (l *Logger) Named(name string, opts ...Option) *Logger {
+	if problem := nameProblem(name); problem != "" {
+		panic("loggy: invalid sub-logger name - " + problem)
+	}
+	l.mu.Lock()
+	child := &Logger{
+		name:          ": " + l.Name() + "." + name + ":",
+		parent:        l,
+		stackLevel:    l.stackLevel,
+		hooks:         slices.Clip(l.hooks),
+		redactor:      l.redactor,
+		panicLevel:    l.panicLevel,
+		errorHandler:  l.errorHandler,
+		fallback:      l.fallback,
+		errorInterval: l.errorInterval,
+		unlisted:      l.unlisted,
+		helper:        l.helper,
+	}
+	l.mu.Unlock()
+	child.minLevel.Store(inheritLevel)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(child)
+		}
+	}
+	if child.overrides != 0 {
+		child.publish()
+	}
+	child.enroll()
+	return child
+}
+
+// Parent returns the Logger the sub-logger was created from with Named, or nil for a
+// Logger created with New.
+func (l *Logger) Parent() *Logger {
+	return l.parent
+}
+
+// InheritLevel makes a sub-logger defer to its parent's level again after SetLevel.
+// It has no effect on Loggers created with New, which have no parent.
+func (l *Logger) InheritLevel() {
+	if l.parent != nil {
+		l.minLevel.Store(inheritLevel)
+	}
+}
+
+// inheritsLevel reports whether the Logger currently defers to its parent's level.
+func (l *Logger) inheritsLevel() bool {
+	return l.minLevel.Load() == inheritLevel
+}
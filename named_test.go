@@ -0,0 +1,151 @@
+package loggy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestNamed verifies the hierarchical name and the inherited writer and settings.
+func TestNamed(t *testing.T) {
+	buf := new(bytes.Buffer)
+	svc := New(": svc:", buf, InfoIssuer, WithCallerStyle(CallerNone), WithSeverityNames([]string{"D ", "I ", "W ", "E ", "F "}))
+	db := svc.Named("db")
+	pool := db.Named("pool", WithCallerFunction(true))
+	if db.Name() != "svc.db" || pool.Name() != "svc.db.pool" {
+		t.Errorf("Unexpected names: %q, %q", db.Name(), pool.Name())
+	}
+	if pool.Parent() != db || db.Parent() != svc || svc.Parent() != nil {
+		t.Error("Unexpected parents")
+	}
+	if err := db.Info("connected"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), ": svc.db:I  connected\n") {
+		t.Errorf("Expected the parent's writer and settings, got: %q", buf.String())
+	}
+}
+
+// TestNamedFollowsParent verifies that writer and layout changes on an ancestor reach
+// sub-loggers, while the settings a sub-logger overrides are kept.
+func TestNamedFollowsParent(t *testing.T) {
+	first, second := new(bytes.Buffer), new(bytes.Buffer)
+	svc := New(": svc:", first, InfoIssuer, WithCallerStyle(CallerNone))
+	db := svc.Named("db")
+	pool := db.Named("pool", WithCallerFunction(true), WithSeverityNames([]string{"d ", "i ", "w ", "e ", "f "}))
+
+	svc.UpdateWriter(second)
+	svc.reconfigure(WithSeverityNames([]string{"D ", "I ", "W ", "E ", "F "}))
+	_ = db.Info("connected")
+	_ = pool.Info("acquired")
+	if first.Len() != 0 {
+		t.Errorf("Expected nothing written to the replaced writer, got: %q", first.String())
+	}
+	if !strings.Contains(second.String(), ": svc.db:I  connected\n") {
+		t.Errorf("Expected db to follow the parent's writer and severity names, got: %q", second.String())
+	}
+	if !strings.Contains(second.String(), ": svc.db.pool:i  acquired\n") {
+		t.Errorf("Expected pool to keep its own severity names, got: %q", second.String())
+	}
+
+	own := new(bytes.Buffer)
+	db.UpdateWriter(own)
+	svc.UpdateWriter(first)
+	_ = pool.Info("released")
+	if !strings.Contains(own.String(), ": svc.db.pool:i  released\n") || first.Len() != 0 {
+		t.Errorf("Expected pool to follow the writer of db, got: %q", own.String())
+	}
+}
+
+// TestNamedReconfigure verifies that reconfiguring a sub-logger repeatedly records only
+// the settings it overrides, while the others still follow its parent.
+func TestNamedReconfigure(t *testing.T) {
+	buf := new(bytes.Buffer)
+	svc := New(": svc:", buf, InfoIssuer, WithCallerStyle(CallerNone))
+	db := svc.Named("db")
+	for i := 0; i < 100; i++ {
+		db.reconfigure(WithTimeFormat("15:04"), WithUTC(true))
+	}
+	if db.overrides != setTimeFormat|setUTC {
+		t.Errorf("Expected only the time format and UTC to be overridden, got: %b", db.overrides)
+	}
+	svc.reconfigure(WithTimeFormat("2006"), WithSeverityNames([]string{"D ", "I ", "W ", "E ", "F "}))
+	ly := db.layout()
+	if ly.timeFormat != "15:04" || !ly.useUTC || ly.severityNames[InfoIssuer] != "I " || ly.callerStyle != CallerNone {
+		t.Errorf("Unexpected sub-logger layout: %+v", ly)
+	}
+	if db.layout() != ly {
+		t.Error("Expected the derived layout to be reused while the parent is unchanged")
+	}
+}
+
+// TestNamedLevelInheritance verifies that SetLevel cascades to sub-loggers without their own level.
+func TestNamedLevelInheritance(t *testing.T) {
+	buf := new(bytes.Buffer)
+	svc := New(": svc:", buf, InfoIssuer)
+	db := svc.Named("db")
+	http := svc.Named("http")
+	pool := db.Named("pool")
+	quiet := svc.Named("quiet", WithLevel(ErrorIssuer))
+
+	svc.SetLevel(DebugIssuer)
+	for _, l := range []*Logger{db, http, pool} {
+		if l.GetLevel() != DebugIssuer {
+			t.Errorf("Expected %s to inherit debug, got: %v", l.Name(), l.GetLevel())
+		}
+	}
+	if quiet.GetLevel() != ErrorIssuer {
+		t.Errorf("Expected the overridden level to be kept, got: %v", quiet.GetLevel())
+	}
+
+	db.SetLevel(WarnIssuer)
+	svc.SetLevel(InfoIssuer)
+	if db.GetLevel() != WarnIssuer || pool.GetLevel() != WarnIssuer || http.GetLevel() != InfoIssuer {
+		t.Errorf("Unexpected levels after overriding db: db=%v pool=%v http=%v", db.GetLevel(), pool.GetLevel(), http.GetLevel())
+	}
+	if pool.Enabled(InfoIssuer) || !pool.Enabled(WarnIssuer) {
+		t.Error("Expected pool to filter by the level of db")
+	}
+
+	db.InheritLevel()
+	if db.GetLevel() != InfoIssuer || pool.GetLevel() != InfoIssuer {
+		t.Errorf("Expected db and pool to inherit again, got: db=%v pool=%v", db.GetLevel(), pool.GetLevel())
+	}
+	svc.InheritLevel()
+	if svc.GetLevel() != InfoIssuer {
+		t.Errorf("Expected InheritLevel to be a no-op on a root Logger, got: %v", svc.GetLevel())
+	}
+}
+
+// TestNamedHooksIsolated verifies that hooks added to a sub-logger do not leak to its parent.
+func TestNamedHooksIsolated(t *testing.T) {
+	buf := new(bytes.Buffer)
+	svc := New(": svc:", buf, DebugIssuer, WithHook(HookFunc(func(e *Entry) bool { return true })))
+	svc.Named("db", WithHook(HookFunc(func(e *Entry) bool { return false })))
+	if err := svc.Info("kept"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	if !strings.Contains(buf.String(), "kept") {
+		t.Errorf("Expected the parent's entry to be written, got: %q", buf.String())
+	}
+}
+
+// TestNamedInvalid verifies that invalid sub-logger names panic.
+func TestNamedInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an invalid name")
+		}
+	}()
+	New(": svc:", new(bytes.Buffer), DebugIssuer).Named("a:b")
+}
+
+// TestNamedInvalidLineBreak verifies that Named rejects the names NewLogger rejects.
+func TestNamedInvalidLineBreak(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a name with a carriage return")
+		}
+	}()
+	New(": svc:", new(bytes.Buffer), DebugIssuer).Named("a\rb")
+}
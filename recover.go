@@ -0,0 +1,107 @@
+package loggy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WithPanicLevel returns an Option that sets the severity at which Recover, Go and
+// RecoverMiddleware log recovered panics. The default is ErrorIssuer, after which execution
+// continues. With FatalIssuer the panic is logged and then re-raised with its original value.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithPanicLevel(FatalIssuer))
+func WithPanicLevel(level Severity) Option {
+	return func(l *Logger) {
+		if level >= DebugIssuer && level <= FatalIssuer {
+			l.panicLevel = level
+		}
+	}
+}
+
+// Recover recovers a panic in the calling goroutine and logs its value together with the
+// stack of the panicking goroutine. The entry's caller is the location of the panic.
+// It must be called directly by a deferred statement; it does nothing otherwise.
+//
+// Example:
+//
+//	func (w *Worker) process(job Job) {
+//		defer w.logger.Recover()
+//		job.Run()
+//	}
+func (l *Logger) Recover() {
+	if v := recover(); v != nil {
+		l.logPanic(v)
+	}
+}
+
+// Go runs fn in a new goroutine, recovering and logging any panic it raises as Recover does.
+//
+// Example:
+//
+//	logger.Go(func() { consume(queue) })
+func (l *Logger) Go(fn func()) {
+	go func() {
+		defer l.Recover()
+		fn()
+	}()
+}
+
+// RecoverMiddleware returns a middleware that recovers panics raised by the wrapped handler,
+// logs them as Recover does and responds with 500 Internal Server Error. The registered
+// context values of the request are added as fields, so that a panic can be correlated
+// with its access log entry. http.ErrAbortHandler is re-raised untouched, as net/http uses
+// it to abort a response silently.
+//
+// Example:
+//
+//	handler := HTTPMiddleware(logger)(RecoverMiddleware(logger)(mux))
+func RecoverMiddleware(l *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(v)
+				}
+				// The header may already have been sent, in which case this is a no-op.
+				w.WriteHeader(http.StatusInternalServerError)
+				l.logPanic(v, contextValues(r.Context(), []interface{}{r.Method, " ", r.URL.RequestURI(), ": "})...)
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// logPanic logs a recovered panic value, preceded by prefix, with the stack of the
+// panicking goroutine, and re-panics if the panic level is FatalIssuer. It must be called
+// from the deferred function that recovered v.
+func (l *Logger) logPanic(v interface{}, prefix ...interface{}) {
+	// Skip logPanic and the deferred function, then drop the runtime frames of the panic
+	// itself so that the stack starts at the panicking statement.
+	stack := captureStack(2)
+	for i, f := range stack {
+		if f.Function == "runtime.gopanic" {
+			stack = stack[i+1:]
+			// Runtime errors pass through further frames such as runtime.sigpanic.
+			for len(stack) > 0 && strings.HasPrefix(stack[0].Function, "runtime.") {
+				stack = stack[1:]
+			}
+			break
+		}
+	}
+	if stack == nil {
+		stack = noCaller
+	}
+	msg := append(prefix, "panic: ", fmt.Sprint(v))
+	_ = l.log(l.panicLevel, stack, msg)
+	if l.panicLevel == FatalIssuer {
+		panic(v)
+	}
+}
@@ -0,0 +1,133 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panicking panics with the given value, recording the line of the panic.
+func panicking(v interface{}, line *int) {
+	_, _, *line, _ = runtime.Caller(0)
+	panic(v)
+}
+
+// TestRecover verifies that a panic is logged with its location and stack, and that
+// execution continues.
+func TestRecover(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	var line int
+	func() {
+		defer logger.Recover()
+		panicking("boom", &line)
+	}()
+	output := buf.String()
+	if !strings.Contains(output, "error:") {
+		t.Errorf("Expected the panic at error level, got: %s", output)
+	}
+	if !strings.Contains(output, " recover_test.go:"+strconv.Itoa(line+1)+": panic: boom\n") {
+		t.Errorf("Expected the panic location as caller, got: %s", output)
+	}
+	if !strings.Contains(output, "\tgithub.com/sivaosorg/loggy.panicking\n") || !strings.Contains(output, "loggy.TestRecover") {
+		t.Errorf("Expected the stack of the panic, got: %s", output)
+	}
+	if strings.Contains(output, "runtime.gopanic") || strings.Contains(output, "logPanic") {
+		t.Errorf("Expected runtime and recovery frames to be omitted, got: %s", output)
+	}
+}
+
+// TestRecoverRuntimeError verifies that the runtime frames of a runtime error are skipped too.
+func TestRecoverRuntimeError(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	func() {
+		defer logger.Recover()
+		var m map[string]int
+		m["x"] = 1
+	}()
+	if !strings.Contains(buf.String(), " recover_test.go:") || !strings.Contains(buf.String(), "panic: assignment to entry in nil map") {
+		t.Errorf("Expected the runtime error at its location, got: %s", buf.String())
+	}
+	if strings.Contains(buf.String(), "runtime.gopanic") || strings.Contains(buf.String(), "runtime.mapassign") {
+		t.Errorf("Expected no runtime frames, got: %s", buf.String())
+	}
+}
+
+// TestRecoverFatal verifies that FatalIssuer logs and then re-panics with the original value.
+func TestRecoverFatal(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithPanicLevel(FatalIssuer))
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Errorf("Expected the original panic value, got: %v", v)
+		}
+		if !strings.Contains(buf.String(), "fatal:") {
+			t.Errorf("Expected the panic at fatal level, got: %s", buf.String())
+		}
+	}()
+	defer logger.Recover()
+	panic("boom")
+}
+
+// chanWriter sends every write to a channel.
+type chanWriter chan string
+
+func (c chanWriter) Write(p []byte) (int, error) {
+	c <- string(p)
+	return len(p), nil
+}
+
+// TestGo verifies that panics in goroutines started with Go are logged instead of crashing.
+func TestGo(t *testing.T) {
+	out := make(chanWriter, 1)
+	logger := New(": test-service:", out, DebugIssuer)
+	logger.Go(func() {
+		panic("worker failed")
+	})
+	select {
+	case entry := <-out:
+		if !strings.Contains(entry, "panic: worker failed") {
+			t.Errorf("Expected the goroutine panic to be logged, got: %s", entry)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the panic to be logged")
+	}
+}
+
+// TestRecoverMiddleware verifies the 500 response, the request fields and the handling of
+// http.ErrAbortHandler.
+func TestRecoverMiddleware(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	h := RecoverMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/abort" {
+			panic(http.ErrAbortHandler)
+		}
+		panic("handler failed")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req = req.WithContext(ContextWithRequestID(context.Background(), "req-9"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", rec.Code)
+	}
+	if !strings.Contains(buf.String(), ": GET /boom: panic: handler failed request_id=req-9\n") {
+		t.Errorf("Expected the request and panic in the entry, got: %s", buf.String())
+	}
+
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be re-raised, got: %v", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+}
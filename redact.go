@@ -0,0 +1,155 @@
+package loggy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithRedactor returns an Option that applies the given Redactor to every entry before
+// hooks run and before it is written, so that secrets never reach either of them.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithRedactor(&Redactor{
+//		Rules:    []RedactRule{BearerTokenRule, CreditCardRule, EmailRule},
+//		DenyKeys: DefaultDenyKeys,
+//		Mode:     RedactHash,
+//	}))
+func WithRedactor(r *Redactor) Option {
+	return func(l *Logger) {
+		l.redactor = r
+	}
+}
+
+// RedactString applies the redaction rules to s and returns the result.
+func (r *Redactor) RedactString(s string) string {
+	for _, rule := range r.Rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if rule.Verify != nil && !rule.Verify(m) {
+				return m
+			}
+			return r.replacement(m)
+		})
+	}
+	return s
+}
+
+// redact applies the redactor to the entry's message and fields in place.
+func (r *Redactor) redact(e *Entry) {
+	e.Message = r.RedactString(e.Message)
+	if len(e.Fields) == 0 {
+		return
+	}
+	e.Fields = r.redactFields(e.Fields)
+}
+
+// redactFields applies the redactor to fields in place and returns the fields kept.
+// ErrorChain values are replaced by a chain whose messages and fields are redacted.
+func (r *Redactor) redactFields(fields []Field) []Field {
+	kept := fields[:0]
+	for _, f := range fields {
+		if r.denied(f.Key) {
+			if r.Mode == RedactDrop {
+				continue
+			}
+			f.Value = r.replacement(fmt.Sprint(f.Value))
+		} else if c, ok := f.Value.(ErrorChain); ok {
+			info := r.redactInfo(c.Info())
+			f.Value = ErrorChain{Err: c.Err, info: &info}
+		} else if s, ok := stringValue(f.Value); ok {
+			redacted := r.RedactString(s)
+			if redacted != s {
+				if r.Mode == RedactDrop {
+					continue
+				}
+				f.Value = redacted
+			}
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// redactInfo returns a copy of info with the messages and fields of every error redacted.
+func (r *Redactor) redactInfo(info ErrorInfo) ErrorInfo {
+	info.Message = r.RedactString(info.Message)
+	if len(info.Fields) > 0 {
+		info.Fields = r.redactFields(slices.Clone(info.Fields))
+	}
+	if len(info.Causes) > 0 {
+		causes := make([]ErrorInfo, len(info.Causes))
+		for i, cause := range info.Causes {
+			causes[i] = r.redactInfo(cause)
+		}
+		info.Causes = causes
+	}
+	return info
+}
+
+// denied reports whether key appears in the deny list, ignoring case.
+func (r *Redactor) denied(key string) bool {
+	for _, k := range r.DenyKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// replacement returns the value written in place of the sensitive value s.
+func (r *Redactor) replacement(s string) string {
+	switch r.Mode {
+	case RedactHash:
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	case RedactDrop:
+		return ""
+	default:
+		return redactedMask
+	}
+}
+
+// stringValue returns the textual form of field values that may carry free-form text:
+// every value except numbers and booleans, which are left untouched by the rules.
+// Structs, maps, slices and other values are matched in their fmt.Sprint form.
+func stringValue(v interface{}) (string, bool) {
+	switch t := v.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return "", false
+	case string:
+		return t, true
+	case []byte:
+		return string(t), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by payment cards.
+// Spaces and dashes are ignored.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
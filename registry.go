@@ -0,0 +1,209 @@
+package loggy
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithoutRegistration returns an Option that keeps the Logger out of the global registry,
+// e.g. for short-lived Loggers that should not be enumerated or matched by SetLevels.
+// Such a Logger has its own entry counters, reported by Stats but not by Metrics, so
+// that nothing refers to it once it is no longer used. Its sub-loggers are kept out of
+// the registry as well. NewTestLogger and loggytest.New use it.
+//
+// Example:
+//
+//	logger := New(": request:", w, DebugIssuer, WithoutRegistration())
+func WithoutRegistration() Option {
+	return func(l *Logger) {
+		l.unlisted = true
+	}
+}
+
+// Register adds l to the global registry under its name, applying the SetLevels rules
+// that match it. Loggers created with New or Named are registered automatically unless
+// WithoutRegistration is used. A Logger registered under an existing name replaces the
+// previous one.
+func Register(l *Logger) {
+	registry.register(l)
+}
+
+// Loggers returns the registered Loggers sorted by name.
+func Loggers() []*Logger {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	loggers := make([]*Logger, 0, len(registry.loggers))
+	for _, l := range registry.loggers {
+		loggers = append(loggers, l)
+	}
+	sort.Slice(loggers, func(i, j int) bool { return loggers[i].Name() < loggers[j].Name() })
+	return loggers
+}
+
+// Lookup returns the registered Logger with the given name.
+func Lookup(name string) (*Logger, bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	l, ok := registry.loggers[name]
+	return l, ok
+}
+
+// SetLevels sets the levels of registered Loggers from a comma-separated list of
+// pattern=level rules, e.g. "db.*=debug,http=warn". Levels are those accepted by
+// ParseSeverity. The rules replace those of any previous call and are also applied to
+// Loggers registered later, so they may be set before the Loggers are created.
+//
+// Patterns are matched against the whole Logger name with a glob syntax close to that
+// of path.Match, except that no character is a separator:
+//
+//   - '*' matches any sequence of characters, including dots and slashes: "db.*" matches
+//     "db.pool" and "db.pool.conn", but not "db" itself nor "svc.db.pool", and "TestAPI*"
+//     matches the Loggers of the subtests "TestAPI/get" and "TestAPI/put";
+//   - '?' matches any single character;
+//   - '[...]' matches a character class, e.g. "worker-[0-9]";
+//   - '\' escapes the following character;
+//   - any other character, including '.', matches itself; "*" matches every Logger.
+//
+// When several rules match a Logger, the last one wins, so general rules should come
+// first: "*=warn,db.*=debug". Loggers matching no rule are left untouched. A malformed
+// list is rejected as a whole with a *ConfigError and changes nothing.
+//
+// Example:
+//
+//	if err := SetLevels(os.Getenv("LOG_LEVELS")); err != nil {
+//		return err
+//	}
+func SetLevels(spec string) error {
+	rules, err := parseLevelRules(spec)
+	if err != nil {
+		return err
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.rules = rules
+	for _, l := range registry.loggers {
+		registry.applyRulesLocked(l)
+	}
+	return nil
+}
+
+// parseLevelRules parses a SetLevels specification.
+func parseLevelRules(spec string) ([]levelRule, error) {
+	var rules []levelRule
+	for _, item := range splitList(spec) {
+		if item == "" {
+			continue
+		}
+		pattern, name, ok := strings.Cut(item, "=")
+		pattern, name = strings.TrimSpace(pattern), strings.TrimSpace(name)
+		if !ok || pattern == "" {
+			return nil, &ConfigError{Field: "levels", Reason: fmt.Sprintf("%q is not of the form pattern=level", item)}
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, &ConfigError{Field: "levels", Reason: fmt.Sprintf("invalid pattern %q", pattern)}
+		}
+		level, err := ParseSeverity(name)
+		if err != nil {
+			return nil, &ConfigError{Field: "levels", Reason: fmt.Sprintf("unknown severity %q for %q", name, pattern)}
+		}
+		rules = append(rules, levelRule{pattern: pattern, level: level})
+	}
+	return rules, nil
+}
+
+// enroll gives l the counters shared by its name and registers it, unless it is kept out
+// of the registry, in which case its counters are its own.
+func (l *Logger) enroll() {
+	if l.unlisted {
+		l.counters = new(loggerCounters)
+		return
+	}
+	l.counters = metrics.forName(l.Name())
+	registry.register(l)
+}
+
+// register adds l to the registry and applies the matching rules.
+func (r *loggerRegistry) register(l *Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loggers[l.Name()] = l
+	r.applyRulesLocked(l)
+}
+
+// applyRulesLocked sets the level of the last rule matching l, if any. r.mu must be held.
+func (r *loggerRegistry) applyRulesLocked(l *Logger) {
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if matchName(r.rules[i].pattern, l.Name()) {
+			l.SetLevel(r.rules[i].level)
+			return
+		}
+	}
+}
+
+// matchName reports whether name matches the SetLevels pattern, which must be valid for
+// path.Match. Unlike path.Match, '*' and '?' also match '/'.
+func matchName(pattern, name string) bool {
+	for pattern != "" {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if pattern == "" {
+				return true
+			}
+			for i := range name {
+				if matchName(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if name == "" {
+				return false
+			}
+			_, n := utf8.DecodeRuneInString(name)
+			pattern, name = pattern[1:], name[n:]
+		case '[':
+			if name == "" {
+				return false
+			}
+			end := classEnd(pattern)
+			r, n := utf8.DecodeRuneInString(name)
+			if ok, _ := path.Match(pattern[:end], string(r)); !ok {
+				return false
+			}
+			pattern, name = pattern[end:], name[n:]
+		default:
+			if pattern[0] == '\\' {
+				pattern = pattern[1:]
+			}
+			_, n := utf8.DecodeRuneInString(pattern)
+			if !strings.HasPrefix(name, pattern[:n]) {
+				return false
+			}
+			pattern, name = pattern[n:], name[n:]
+		}
+	}
+	return name == ""
+}
+
+// classEnd returns the length of the character class at the start of pattern, up to and
+// including its closing ']'.
+func classEnd(pattern string) int {
+	i := 1
+	if i < len(pattern) && pattern[i] == '^' {
+		i++
+	}
+	for i < len(pattern) {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case ']':
+			return i + 1
+		}
+		i++
+	}
+	return len(pattern)
+}
@@ -0,0 +1,165 @@
+package loggy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRegistry verifies automatic registration, lookup and opting out.
+func TestRegistry(t *testing.T) {
+	svc := New(": reg-svc:", io.Discard, InfoIssuer)
+	db := svc.Named("db")
+	hidden := New(": reg-hidden:", io.Discard, InfoIssuer, WithoutRegistration())
+	if l, ok := Lookup("reg-svc"); !ok || l != svc {
+		t.Error("Expected New to register the Logger by name")
+	}
+	if l, ok := Lookup("reg-svc.db"); !ok || l != db {
+		t.Error("Expected Named to register the sub-logger")
+	}
+	if _, ok := Lookup("reg-hidden"); ok {
+		t.Error("Expected WithoutRegistration to keep the Logger out of the registry")
+	}
+	Register(hidden)
+	if _, ok := Lookup("reg-hidden"); !ok {
+		t.Error("Expected Register to add the Logger")
+	}
+	loggers := Loggers()
+	for i := 1; i < len(loggers); i++ {
+		if loggers[i-1].Name() >= loggers[i].Name() {
+			t.Fatalf("Expected Loggers sorted by name, got %q before %q", loggers[i-1].Name(), loggers[i].Name())
+		}
+	}
+}
+
+// TestTestLoggersUnregistered verifies that test Loggers and their sub-loggers leave
+// nothing behind in the registry or the metrics.
+func TestTestLoggersUnregistered(t *testing.T) {
+	l := NewTestLogger(t)
+	child := l.Named("child")
+	_ = child.Info("hello")
+	for _, name := range []string{l.Name(), child.Name()} {
+		if _, ok := Lookup(name); ok {
+			t.Errorf("Expected %s to be kept out of the registry", name)
+		}
+		if _, ok := Metrics()[name]; ok {
+			t.Errorf("Expected no shared counters for %s", name)
+		}
+	}
+	if child.Stats(InfoIssuer).Emitted != 1 {
+		t.Errorf("Expected the sub-logger to count its own entries, got: %+v", child.Stats(InfoIssuer))
+	}
+}
+
+// TestSetLevelsGlob verifies the glob semantics and rule precedence of SetLevels.
+func TestSetLevelsGlob(t *testing.T) {
+	t.Cleanup(func() { SetLevels("") })
+	names := []string{"glob", "glob.db", "glob.db.pool", "glob.http", "svc.glob.db", "glob.worker-1", "glob.worker-x"}
+	loggers := make(map[string]*Logger)
+	for _, n := range names {
+		loggers[n] = New(": "+n+":", io.Discard, InfoIssuer)
+	}
+
+	if err := SetLevels("glob*=error, glob.db.*=debug, glob.http=warn, glob.worker-[0-9]=fatal"); err != nil {
+		t.Fatalf("Unexpected error from SetLevels: %v", err)
+	}
+	want := map[string]Severity{
+		"glob":          ErrorIssuer, // "glob*" matches the empty suffix
+		"glob.db":       ErrorIssuer, // "glob.db.*" requires a dot after db
+		"glob.db.pool":  DebugIssuer, // later rules take precedence
+		"glob.http":     WarnIssuer,
+		"svc.glob.db":   InfoIssuer, // patterns are anchored at the start of the name
+		"glob.worker-1": FatalIssuer,
+		"glob.worker-x": ErrorIssuer,
+	}
+	for n, lv := range want {
+		if got := loggers[n].GetLevel(); got != lv {
+			t.Errorf("%s: expected %v, got %v", n, lv, got)
+		}
+	}
+
+	// Rules also apply to Loggers registered later, overriding the level given to New.
+	late := New(": glob.db.cache:", io.Discard, InfoIssuer)
+	if late.GetLevel() != DebugIssuer {
+		t.Errorf("Expected a later Logger to get the rule's level, got %v", late.GetLevel())
+	}
+}
+
+// TestSetLevelsSlash verifies that '*' and '?' match slashes, as in subtest names.
+func TestSetLevelsSlash(t *testing.T) {
+	t.Cleanup(func() { SetLevels("") })
+	sub := New(": TestSlash/sub:", io.Discard, InfoIssuer)
+	deep := New(": TestSlash/sub/case_1:", io.Discard, InfoIssuer)
+	other := New(": TestSlashes:", io.Discard, InfoIssuer)
+
+	if err := SetLevels("TestSlash*=debug, TestSlash?sub?case_[0-9]=error, TestSlash\\/sub=fatal"); err != nil {
+		t.Fatalf("Unexpected error from SetLevels: %v", err)
+	}
+	if sub.GetLevel() != FatalIssuer || deep.GetLevel() != ErrorIssuer || other.GetLevel() != DebugIssuer {
+		t.Errorf("Unexpected levels: sub=%v deep=%v other=%v", sub.GetLevel(), deep.GetLevel(), other.GetLevel())
+	}
+	// Avoid "*" alone, which would change the level of every Logger of the package tests.
+	if err := SetLevels("*/sub*=warn"); err != nil {
+		t.Fatalf("Unexpected error from SetLevels: %v", err)
+	}
+	if sub.GetLevel() != WarnIssuer || deep.GetLevel() != WarnIssuer {
+		t.Errorf("Expected \"*/sub*\" to match across slashes, got: sub=%v deep=%v", sub.GetLevel(), deep.GetLevel())
+	}
+}
+
+// TestLevelHandlerReregistered verifies that a registry-backed LevelHandler serves the
+// Logger currently registered under a name, not one it saw before.
+func TestLevelHandlerReregistered(t *testing.T) {
+	h := NewLevelHandler()
+	old := New(": probe-admin:", io.Discard, InfoIssuer)
+	if _, err := h.SetLevel("probe-admin", WarnIssuer, 0); err != nil {
+		t.Fatalf("Unexpected error from SetLevel: %v", err)
+	}
+	current := New(": probe-admin:", io.Discard, InfoIssuer)
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name": "probe-admin", "level": "debug"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if current.GetLevel() != DebugIssuer || old.GetLevel() != WarnIssuer {
+		t.Errorf("Expected only the registered Logger to change, got: current=%v old=%v", current.GetLevel(), old.GetLevel())
+	}
+}
+
+// TestSetLevelsInvalid verifies that malformed rules are rejected without changing levels.
+func TestSetLevelsInvalid(t *testing.T) {
+	t.Cleanup(func() { SetLevels("") })
+	l := New(": invalid-rules:", io.Discard, InfoIssuer)
+	for _, spec := range []string{"invalid-rules", "=debug", "invalid-rules=loud", "[=debug", "invalid-rules=debug,x"} {
+		err := SetLevels(spec)
+		var ce *ConfigError
+		if !errors.As(err, &ce) || ce.Field != "levels" {
+			t.Errorf("%q: expected a *ConfigError, got: %v", spec, err)
+		}
+	}
+	if l.GetLevel() != InfoIssuer {
+		t.Errorf("Expected the level to be unchanged, got %v", l.GetLevel())
+	}
+}
+
+// TestLevelHandlerRegistry verifies that a LevelHandler without Loggers serves the registry.
+func TestLevelHandlerRegistry(t *testing.T) {
+	h := NewLevelHandler()
+	l := New(": handler-registry:", io.Discard, InfoIssuer)
+	if _, err := h.SetLevel("handler-registry", ErrorIssuer, 0); err != nil {
+		t.Fatalf("Unexpected error from SetLevel: %v", err)
+	}
+	if l.GetLevel() != ErrorIssuer {
+		t.Errorf("Expected the registered Logger to be updated, got %v", l.GetLevel())
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=handler-registry", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", rec.Code)
+	}
+}
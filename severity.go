@@ -0,0 +1,36 @@
+package loggy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// severityStrings holds the canonical lower-case name of each severity, indexed by value.
+var severityStrings = [...]string{"debug", "info", "warn", "error", "fatal", "disable"}
+
+// String returns the canonical lower-case name of the severity (e.g. "info"),
+// independent of any custom labels configured with WithSeverityNames.
+func (s Severity) String() string {
+	if int(s) < len(severityStrings) {
+		return severityStrings[s]
+	}
+	return fmt.Sprintf("severity(%d)", uint32(s))
+}
+
+// ParseSeverity returns the severity named by s. Matching is case-insensitive, and
+// "warning" and "off" are accepted as aliases of "warn" and "disable" respectively.
+func ParseSeverity(s string) (Severity, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	switch name {
+	case "warning":
+		return WarnIssuer, nil
+	case "off", "none":
+		return DisableIssuer, nil
+	}
+	for i, v := range severityStrings {
+		if v == name {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("loggy: unknown severity %q", s)
+}
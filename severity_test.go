@@ -0,0 +1,22 @@
+package loggy
+
+import "testing"
+
+// TestParseSeverity verifies that names, aliases and String round-trip.
+func TestParseSeverity(t *testing.T) {
+	for lv := DebugIssuer; lv <= DisableIssuer; lv++ {
+		got, err := ParseSeverity(lv.String())
+		if err != nil || got != lv {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", lv.String(), got, err, lv)
+		}
+	}
+	aliases := map[string]Severity{"WARNING": WarnIssuer, " Info ": InfoIssuer, "off": DisableIssuer}
+	for s, want := range aliases {
+		if got, err := ParseSeverity(s); err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseSeverity("verbose"); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
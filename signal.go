@@ -0,0 +1,11 @@
+//go:build !js
+
+package loggy
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals lists the signals that make a ConfigWatcher reload its file.
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,8 @@
+//go:build js
+
+package loggy
+
+import "os"
+
+// reloadSignals is empty on platforms without SIGHUP; ConfigWatcher relies on polling alone.
+var reloadSignals []os.Signal
@@ -0,0 +1,62 @@
+package loggy
+
+import "runtime"
+
+// maxStackDepth bounds the number of frames captured for a single stack trace.
+const maxStackDepth = 64
+
+// WithStackTrace returns an Option that attaches the full call stack to every entry
+// logged at or above the given severity. The stack starts at the same frame as the
+// reported caller, so wrapper frames skipped with a Caller argument are removed too.
+// In the text layout each frame is written as indented continuation lines below the message.
+// Passing DisableIssuer turns stack traces off, which is the default.
+//
+// Example:
+//
+//	logger := New(": my-service:", os.Stdout, DebugIssuer, WithStackTrace(ErrorIssuer))
+func WithStackTrace(level Severity) Option {
+	return func(l *Logger) {
+		if level <= DisableIssuer {
+			l.stackLevel = level
+		}
+	}
+}
+
+// captureStack returns up to maxStackDepth frames starting skip levels above its own
+// caller, using the same numbering as runtime.Caller.
+func captureStack(skip int) []Frame {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	if n == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make([]Frame, 0, n)
+	for {
+		f, more := frames.Next()
+		if f.File != "" {
+			stack = append(stack, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// writeStack writes each frame as two indented continuation lines, mirroring the
+// layout of Go's own panic traces:
+//
+//	\tpkg.Function
+//	\t\t/path/to/file.go:42
+func writeStack(b *buffer, stack []Frame) {
+	for _, f := range stack {
+		b.WriteByte('\t')
+		b.WriteString(f.Function)
+		b.WriteString("\n\t\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteInt(int64(f.Line))
+		b.WriteByte('\n')
+	}
+}
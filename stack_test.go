@@ -0,0 +1,54 @@
+package loggy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// logThroughWrapper logs via an extra frame that is removed with a Caller argument.
+func logThroughWrapper(l *Logger, msg string) error {
+	return l.Error(Caller(1), msg)
+}
+
+// TestStackTraceThreshold verifies that stack traces are only attached at or above the configured level.
+func TestStackTraceThreshold(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithStackTrace(ErrorIssuer))
+	if err := logger.Warn("no stack"); err != nil {
+		t.Errorf("Unexpected error from Warn: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected a single line for a warning, got: %q", buf.String())
+	}
+	buf.Reset()
+	if err := logger.Error("with stack"); err != nil {
+		t.Errorf("Unexpected error from Error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected stack continuation lines, got: %q", buf.String())
+	}
+	if lines[1] != "\tgithub.com/sivaosorg/loggy.TestStackTraceThreshold" {
+		t.Errorf("Expected the first frame to be the caller, got: %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "\t\t") || !strings.Contains(lines[2], "stack_test.go:") {
+		t.Errorf("Expected an indented file location, got: %q", lines[2])
+	}
+}
+
+// TestStackTraceHonoursCaller verifies that frames skipped with Caller are removed from the stack.
+func TestStackTraceHonoursCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithStackTrace(ErrorIssuer))
+	if err := logThroughWrapper(logger, "wrapped"); err != nil {
+		t.Errorf("Unexpected error from wrapper: %v", err)
+	}
+	output := buf.String()
+	if strings.Contains(output, "logThroughWrapper") {
+		t.Errorf("Expected wrapper frame to be skipped, got: %s", output)
+	}
+	if !strings.Contains(output, "\tgithub.com/sivaosorg/loggy.TestStackTraceHonoursCaller\n") {
+		t.Errorf("Expected stack to start at the test function, got: %s", output)
+	}
+}
@@ -0,0 +1,72 @@
+package loggy
+
+import (
+	"log"
+	"runtime"
+	"strings"
+)
+
+// StdLogger returns a *log.Logger from the standard library that writes every message
+// through l at the given severity. The reported caller is the code that called the
+// *log.Logger (e.g. http.Server reporting an error), not the log package itself.
+// This makes it suitable for APIs that expect a *log.Logger, such as http.Server.ErrorLog.
+//
+// Example:
+//
+//	srv := &http.Server{Addr: ":8080", ErrorLog: logger.StdLogger(ErrorIssuer)}
+func (l *Logger) StdLogger(level Severity) *log.Logger {
+	return log.New(&stdLogWriter{logger: l, level: level}, "", 0)
+}
+
+// RedirectStdLog redirects the output of the standard library's global logger (log.Print,
+// log.Printf, ...) to l at the given severity. The prefix and flags of the global logger
+// are cleared, as the timestamp and caller are provided by l. It returns a function that
+// restores the previous output, prefix and flags.
+//
+// Example:
+//
+//	restore := RedirectStdLog(logger, InfoIssuer)
+//	defer restore()
+func RedirectStdLog(l *Logger, level Severity) func() {
+	prevWriter, prevFlags, prevPrefix := log.Writer(), log.Flags(), log.Prefix()
+	log.SetOutput(&stdLogWriter{logger: l, level: level})
+	log.SetFlags(0)
+	log.SetPrefix("")
+	return func() {
+		log.SetOutput(prevWriter)
+		log.SetFlags(prevFlags)
+		log.SetPrefix(prevPrefix)
+	}
+}
+
+// Write logs p as a single entry, reporting the first caller outside the log package.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	if !w.logger.admit(w.level) {
+		return len(p), nil
+	}
+	msg := strings.TrimSuffix(string(p), "\n")
+	if err := w.logger.Log(w.level, stdLogCaller(), msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// stdLogCaller returns the Caller skip that makes Log, when called from
+// stdLogWriter.Write, report the first frame outside the standard log package.
+// The number of frames inside the log package differs between Go releases and
+// between the global functions and *log.Logger methods, so it is counted at runtime.
+func stdLogCaller() Caller {
+	var pcs [16]uintptr
+	// Skip runtime.Callers, stdLogCaller and stdLogWriter.Write.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	depth := 0
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "log.") || !more {
+			break
+		}
+		depth++
+	}
+	return Caller(depth)
+}
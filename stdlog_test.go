@@ -0,0 +1,53 @@
+package loggy
+
+import (
+	"bytes"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestStdLogger verifies that a *log.Logger writes through the Logger with the original caller.
+func TestStdLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	std := logger.StdLogger(WarnIssuer)
+	_, _, line, _ := runtime.Caller(0)
+	std.Printf("disk %d%% full", 91)
+	want := "warn: stdlog_test.go:" + strconv.Itoa(line+1) + ": disk 91% full\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected output to end with %q, got: %q", want, buf.String())
+	}
+}
+
+// TestStdLoggerFiltered verifies that messages below the Logger's level are discarded.
+func TestStdLoggerFiltered(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, ErrorIssuer)
+	logger.StdLogger(InfoIssuer).Print("ignored")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got: %s", buf.String())
+	}
+}
+
+// TestRedirectStdLog verifies that the global logger is captured and restored.
+func TestRedirectStdLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	prevWriter, prevFlags := log.Writer(), log.Flags()
+
+	restore := RedirectStdLog(logger, InfoIssuer)
+	_, _, line, _ := runtime.Caller(0)
+	log.Println("from the standard library")
+	restore()
+
+	want := "info: stdlog_test.go:" + strconv.Itoa(line+1) + ": from the standard library\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("Expected output to end with %q, got: %q", want, buf.String())
+	}
+	if log.Writer() != prevWriter || log.Flags() != prevFlags {
+		t.Error("Expected the global logger to be restored")
+	}
+}
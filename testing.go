@@ -0,0 +1,98 @@
+package loggy
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testWriter is an io.Writer that forwards each log entry to testing.TB.Log.
+type testWriter struct {
+	mu   sync.Mutex
+	tb   testing.TB
+	done bool // Set once the test has finished; later writes are discarded.
+}
+
+// NewTestWriter returns an io.Writer that sends every entry to t.Log, so that output is
+// attributed to the test that produced it and only shown for failing or verbose tests.
+// Writes made after the test has finished are silently discarded instead of triggering
+// the "Log in goroutine after Test has completed" panic.
+func NewTestWriter(t testing.TB) io.Writer {
+	w := &testWriter{tb: t}
+	t.Cleanup(func() {
+		w.mu.Lock()
+		w.done = true
+		w.mu.Unlock()
+	})
+	return w
+}
+
+// Write logs p through the test, dropping the trailing newline added by the Logger.
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.tb.Helper()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.done {
+		w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
+
+// NewTestLogger returns a Logger named after the test that writes every entry to t.Log
+// at DebugIssuer. The logging methods are marked as test helpers, so t.Log attributes
+// each line to the code that called the Logger rather than to loggy itself. The Logger
+// is kept out of the global registry (see WithoutRegistration), so that it is released
+// with the test.
+//
+// Example:
+//
+//	func TestServer(t *testing.T) {
+//		t.Parallel()
+//		srv := NewServer(loggy.NewTestLogger(t, loggy.WithTestFailure(t, loggy.ErrorIssuer)))
+//		...
+//	}
+func NewTestLogger(t testing.TB, opts ...Option) *Logger {
+	opts = append([]Option{WithoutRegistration()}, opts...)
+	l := New(": "+t.Name()+":", NewTestWriter(t), DebugIssuer, opts...)
+	l.helper = t
+	return l
+}
+
+// WithTestFailure returns an Option that marks the test as failed whenever an entry at or
+// above the given severity is logged. The entry itself is still written as usual.
+// Entries logged after the test has finished do not affect its result.
+func WithTestFailure(t testing.TB, level Severity) Option {
+	var mu sync.Mutex
+	done := false
+	t.Cleanup(func() {
+		mu.Lock()
+		done = true
+		mu.Unlock()
+	})
+	return WithHook(HookFunc(func(e *Entry) bool {
+		if e.Level >= level {
+			mu.Lock()
+			if !done {
+				t.Fail()
+			}
+			mu.Unlock()
+		}
+		return true
+	}))
+}
+
+// Helper does nothing.
+func (nopHelper) Helper() {}
+
+// testHelper returns the marker on which every function of the logging path calls Helper,
+// so that Loggers created by NewTestLogger attribute entries to their caller. Helper marks
+// the function calling it, so the call is made in each function rather than wrapped:
+//
+//	l.testHelper().Helper()
+func (l *Logger) testHelper() interface{ Helper() } {
+	if l.helper == nil {
+		return nopHelper{}
+	}
+	return l.helper
+}
@@ -0,0 +1,123 @@
+package loggy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// recordingTB captures the calls NewTestLogger makes on a testing.TB.
+type recordingTB struct {
+	testing.TB
+	logs     []string
+	cleanups []func()
+	failed   bool
+}
+
+func (r *recordingTB) Name() string            { return "TestRecording" }
+func (r *recordingTB) Helper()                 {}
+func (r *recordingTB) Log(args ...interface{}) { r.logs = append(r.logs, args[0].(string)) }
+func (r *recordingTB) Fail()                   { r.failed = true }
+func (r *recordingTB) Cleanup(f func())        { r.cleanups = append(r.cleanups, f) }
+func (r *recordingTB) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+// TestNewTestLogger verifies that entries are sent to t.Log without the trailing newline.
+func TestNewTestLogger(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	logger := NewTestLogger(tb)
+	_ = logger.Info("hello")
+	if len(tb.logs) != 1 {
+		t.Fatalf("Expected one t.Log call, got %d", len(tb.logs))
+	}
+	if !strings.Contains(tb.logs[0], ": TestRecording:info:") || !strings.HasSuffix(tb.logs[0], "hello") {
+		t.Errorf("Unexpected t.Log output: %q", tb.logs[0])
+	}
+}
+
+// TestNewTestLoggerAfterCompletion verifies that writes after the test has finished are dropped.
+func TestNewTestLoggerAfterCompletion(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	logger := NewTestLogger(tb)
+	tb.finish()
+	_ = logger.Error("late")
+	if len(tb.logs) != 0 {
+		t.Errorf("Expected no t.Log calls after completion, got %q", tb.logs)
+	}
+
+	var leaked *Logger
+	t.Run("sub", func(t *testing.T) {
+		leaked = NewTestLogger(t)
+	})
+	// Would panic with "Log in goroutine after Test has completed" if not guarded.
+	_ = leaked.Info("after subtest")
+}
+
+// TestWithTestFailure verifies that entries at or above the level fail the test.
+func TestWithTestFailure(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	logger := NewTestLogger(tb, WithTestFailure(tb, ErrorIssuer))
+	_ = logger.Warn("tolerated")
+	if tb.failed {
+		t.Fatal("Expected a warning not to fail the test")
+	}
+	_ = logger.Error("not tolerated")
+	if !tb.failed {
+		t.Error("Expected an error entry to fail the test")
+	}
+
+	tb = &recordingTB{TB: t}
+	logger = NewTestLogger(tb, WithTestFailure(tb, ErrorIssuer))
+	tb.finish()
+	_ = logger.Error("late")
+	if tb.failed {
+		t.Error("Expected entries after completion not to fail the test")
+	}
+}
+
+// TestNewTestLoggerAttribution runs a real subtest in a child process and verifies that
+// t.Log attributes every entry to the line that called the Logger rather than to loggy.
+func TestNewTestLoggerAttribution(t *testing.T) {
+	if os.Getenv("LOGGY_ATTRIBUTION_CHILD") == "1" {
+		t.Run("sub", func(t *testing.T) {
+			logger := NewTestLogger(t)
+			ctx := NewContext(context.Background(), logger)
+			_, _, line, _ := runtime.Caller(0)
+			logger.Info("plain")
+			logger.Infof("formatted %d", 2)
+			logger.InfoCtx(ctx, "context")
+			InfoCtx(ctx, "package context")
+			logger.InfoFn(func() string { return "lazy" })
+			logger.Named("child").Info("named")
+			fmt.Printf("FIRST_LINE=%d\n", line+1)
+		})
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestNewTestLoggerAttribution$", "-test.v")
+	cmd.Env = append(os.Environ(), "LOGGY_ATTRIBUTION_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Child test failed: %v\n%s", err, out)
+	}
+	m := regexp.MustCompile(`FIRST_LINE=(\d+)`).FindSubmatch(out)
+	if m == nil {
+		t.Fatalf("Child test did not report its line, got:\n%s", out)
+	}
+	var first int
+	fmt.Sscan(string(m[1]), &first)
+	for i, msg := range []string{"plain", "formatted 2", "context", "package context", "lazy", "named"} {
+		// t.Log prefixes each entry with the attributed location, before loggy's own layout.
+		want := regexp.MustCompile(fmt.Sprintf(`(?m)^\s+testing_test\.go:%d: .*: %s$`, first+i, regexp.QuoteMeta(msg)))
+		if !want.Match(out) {
+			t.Errorf("Expected %q to be attributed to testing_test.go:%d, got:\n%s", msg, first+i, out)
+		}
+	}
+}
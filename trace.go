@@ -0,0 +1,149 @@
+package loggy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ParseTraceparent parses a W3C traceparent header value of the form
+// "version-traceid-spanid-flags", e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// Values of future versions are accepted as long as their first four fields are well formed.
+//
+// Example:
+//
+//	tc, err := ParseTraceparent(r.Header.Get("traceparent"))
+func ParseTraceparent(header string) (TraceContext, error) {
+	var tc TraceContext
+	h := strings.TrimSpace(header)
+	if len(h) < 55 || (len(h) > 55 && h[55] != '-') {
+		return tc, errors.New("loggy: traceparent: invalid length")
+	}
+	if h[2] != '-' || h[35] != '-' || h[52] != '-' {
+		return tc, errors.New("loggy: traceparent: invalid format")
+	}
+	version, ok := decodeLowerHex(h[0:2])
+	if !ok || version[0] == 0xff {
+		return tc, errors.New("loggy: traceparent: invalid version")
+	}
+	if version[0] == 0 && len(h) != 55 {
+		return tc, errors.New("loggy: traceparent: invalid length")
+	}
+	trace, ok := decodeLowerHex(h[3:35])
+	if !ok {
+		return tc, errors.New("loggy: traceparent: invalid trace-id")
+	}
+	span, ok := decodeLowerHex(h[36:52])
+	if !ok {
+		return tc, errors.New("loggy: traceparent: invalid parent-id")
+	}
+	flags, ok := decodeLowerHex(h[53:55])
+	if !ok {
+		return tc, errors.New("loggy: traceparent: invalid trace-flags")
+	}
+	copy(tc.TraceID[:], trace)
+	copy(tc.SpanID[:], span)
+	tc.Flags = flags[0]
+	if !tc.IsValid() {
+		return TraceContext{}, errors.New("loggy: traceparent: all-zero trace-id or parent-id")
+	}
+	return tc, nil
+}
+
+// NewTraceContext starts a new sampled trace with random trace and span IDs.
+func NewTraceContext() TraceContext {
+	tc := TraceContext{Flags: 0x01}
+	_, _ = rand.Read(tc.TraceID[:])
+	_, _ = rand.Read(tc.SpanID[:])
+	return tc
+}
+
+// NewSpan returns a copy of tc with a new random span ID, for work done on behalf of the
+// span tc identifies (e.g. an incoming or outgoing request).
+func (tc TraceContext) NewSpan() TraceContext {
+	_, _ = rand.Read(tc.SpanID[:])
+	return tc
+}
+
+// IsValid reports whether both the trace ID and the span ID are non-zero.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != [16]byte{} && tc.SpanID != [8]byte{}
+}
+
+// Sampled reports whether the sampled flag is set.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&0x01 != 0
+}
+
+// TraceIDString returns the trace ID as 32 lowercase hexadecimal digits.
+func (tc TraceContext) TraceIDString() string {
+	return hex.EncodeToString(tc.TraceID[:])
+}
+
+// SpanIDString returns the span ID as 16 lowercase hexadecimal digits.
+func (tc TraceContext) SpanIDString() string {
+	return hex.EncodeToString(tc.SpanID[:])
+}
+
+// Traceparent renders tc as a version 00 traceparent header value.
+func (tc TraceContext) Traceparent() string {
+	var b [55]byte
+	copy(b[:], "00-")
+	hex.Encode(b[3:35], tc.TraceID[:])
+	b[35] = '-'
+	hex.Encode(b[36:52], tc.SpanID[:])
+	b[52] = '-'
+	hex.Encode(b[53:55], []byte{tc.Flags})
+	return string(b[:])
+}
+
+// Inject sets the traceparent and, if present, tracestate headers of h from tc.
+func (tc TraceContext) Inject(h http.Header) {
+	h.Set(TraceparentHeader, tc.Traceparent())
+	if tc.State != "" {
+		h.Set(TracestateHeader, tc.State)
+	} else {
+		h.Del(TracestateHeader)
+	}
+}
+
+// TraceFromRequest extracts the trace context from the traceparent and tracestate headers
+// of r. It reports false if traceparent is missing or malformed, in which case tracestate
+// is ignored too, as the specification requires. Multiple tracestate headers are combined;
+// a tracestate longer than 512 bytes is discarded.
+func TraceFromRequest(r *http.Request) (TraceContext, bool) {
+	tc, err := ParseTraceparent(r.Header.Get(TraceparentHeader))
+	if err != nil {
+		return TraceContext{}, false
+	}
+	if state := strings.Join(r.Header.Values(TracestateHeader), ","); len(state) <= maxTracestateLength {
+		tc.State = strings.TrimSpace(state)
+	}
+	return tc, true
+}
+
+// ContextWithTrace returns a copy of ctx carrying tc. The Ctx logging methods then add
+// its IDs as the trace_id and span_id fields.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey, tc)
+}
+
+// TraceFromContext returns the trace context stored in ctx, reporting false if there is none.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceKey).(TraceContext)
+	return tc, ok && tc.IsValid()
+}
+
+// decodeLowerHex decodes s, which must consist of lowercase hexadecimal digits only.
+func decodeLowerHex(s string) ([]byte, bool) {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return nil, false
+		}
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil
+}
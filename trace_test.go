@@ -0,0 +1,118 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestParseTraceparent verifies parsing and validation of traceparent values.
+func TestParseTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tc, err := ParseTraceparent(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tc.TraceIDString() != "4bf92f3577b34da6a3ce929d0e0e4736" || tc.SpanIDString() != "00f067aa0ba902b7" || !tc.Sampled() {
+		t.Errorf("Unexpected trace context: %+v", tc)
+	}
+	if tc.Traceparent() != valid {
+		t.Errorf("Expected round trip to %q, got: %q", valid, tc.Traceparent())
+	}
+	if _, err := ParseTraceparent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future"); err != nil {
+		t.Errorf("Expected a future version with extra fields to be accepted, got: %v", err)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0g",
+	} {
+		if _, err := ParseTraceparent(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
+
+// TestNewTraceContext verifies generated contexts and spans.
+func TestNewTraceContext(t *testing.T) {
+	tc := NewTraceContext()
+	if !tc.IsValid() || !tc.Sampled() {
+		t.Errorf("Expected a valid sampled context, got: %+v", tc)
+	}
+	child := tc.NewSpan()
+	if child.TraceID != tc.TraceID || child.SpanID == tc.SpanID {
+		t.Errorf("Expected a new span in the same trace, got: %+v from %+v", child, tc)
+	}
+	if parsed, err := ParseTraceparent(child.Traceparent()); err != nil || parsed != child {
+		t.Errorf("Expected generated traceparent to parse back, got: %+v, %v", parsed, err)
+	}
+}
+
+// TestTraceFields verifies that Ctx methods add trace_id and span_id.
+func TestTraceFields(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	tc, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err := logger.InfoCtx(ContextWithTrace(context.Background(), tc), "traced"); err != nil {
+		t.Errorf("Unexpected error from InfoCtx: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), ": traced trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7\n") {
+		t.Errorf("Expected trace fields, got: %q", buf.String())
+	}
+}
+
+// TestTracePropagation verifies that the middleware continues an incoming trace and the
+// transport injects it into outgoing requests.
+func TestTracePropagation(t *testing.T) {
+	var outgoing http.Header
+	backend := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		outgoing = r.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	client := &http.Client{Transport: NewTransport(logger, backend)}
+
+	var server TraceContext
+	h := HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server, _ = TraceFromContext(r.Context())
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, "http://example.invalid/", nil)
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("tracestate", "vendor=value")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if server.TraceIDString() != "4bf92f3577b34da6a3ce929d0e0e4736" || server.SpanIDString() == "00f067aa0ba902b7" || server.State != "vendor=value" {
+		t.Errorf("Expected the incoming trace to be continued with a new span, got: %+v", server)
+	}
+	sent, err := ParseTraceparent(outgoing.Get("traceparent"))
+	if err != nil || sent.TraceID != server.TraceID || sent.SpanID == server.SpanID {
+		t.Errorf("Expected a child span to be injected, got: %q (%v)", outgoing.Get("traceparent"), err)
+	}
+	if outgoing.Get("tracestate") != "vendor=value" {
+		t.Errorf("Expected tracestate to be propagated, got: %q", outgoing.Get("tracestate"))
+	}
+	if n := strings.Count(buf.String(), "trace_id=4bf92f3577b34da6a3ce929d0e0e4736"); n != 2 {
+		t.Errorf("Expected both the client and access entries to carry the trace ID, got %d in: %s", n, buf.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "garbage")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !server.IsValid() || server.State != "" {
+		t.Errorf("Expected a new trace for a malformed traceparent, got: %+v", server)
+	}
+}
@@ -0,0 +1,131 @@
+package loggy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WithBodyCapture returns a TransportOption that logs up to limit bytes of the request and
+// response bodies, together with their headers, in an additional DebugIssuer entry.
+// Bodies are only read when DebugIssuer is enabled and are replayed unchanged to the
+// server and to the caller. A limit of zero or less disables capture (the default).
+func WithBodyCapture(limit int) TransportOption {
+	return func(c *transportConfig) {
+		c.bodyLimit = limit
+	}
+}
+
+// WithRedactedHeaders returns a TransportOption that masks the values of the given headers
+// in captured output, in addition to DefaultRedactedHeaders.
+func WithRedactedHeaders(names ...string) TransportOption {
+	return func(c *transportConfig) {
+		for _, name := range names {
+			c.redactHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// NewTransport returns an http.RoundTripper that sends requests through next
+// (http.DefaultTransport if nil) and logs each of them through l with the method, URL,
+// status, response size and latency. The severity follows the status class as in
+// HTTPMiddleware; transport errors are logged at ErrorIssuer with the latency. Entries
+// include the registered context values of the request context, as with InfoCtx.
+//
+// A request ID stored in the request context (see RequestIDFromContext) is sent in the
+// DefaultRequestIDHeader header unless the request already sets it, and a trace context
+// (see TraceFromContext) is continued in the traceparent and tracestate headers with a
+// new span unless the request already carries a traceparent.
+//
+// Example:
+//
+//	client := &http.Client{Transport: NewTransport(logger, nil, WithBodyCapture(4<<10))}
+func NewTransport(l *Logger, next http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	cfg := transportConfig{redactHeaders: make(map[string]bool)}
+	for _, name := range DefaultRedactedHeaders {
+		cfg.redactHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &loggingTransport{logger: l, next: next, config: cfg}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request, so work on a shallow copy.
+	req = req.Clone(req.Context())
+	if id := RequestIDFromContext(req.Context()); id != "" && req.Header.Get(DefaultRequestIDHeader) == "" {
+		req.Header.Set(DefaultRequestIDHeader, id)
+	}
+	if tc, ok := TraceFromContext(req.Context()); ok && req.Header.Get(TraceparentHeader) == "" {
+		tc.NewSpan().Inject(req.Header)
+	}
+	capture := t.config.bodyLimit > 0 && t.logger.Enabled(DebugIssuer)
+	var reqBody []byte
+	if capture && req.Body != nil && req.Body != http.NoBody {
+		reqBody, req.Body = captureBody(req.Body, t.config.bodyLimit)
+	}
+	target := req.Method + " " + req.URL.Redacted()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		_ = t.logger.log(ErrorIssuer, noCaller, contextValues(req.Context(), []interface{}{target, F("error", err), F("duration", elapsed)}))
+		return nil, err
+	}
+
+	if capture {
+		var respBody []byte
+		if resp.Body != nil && resp.Body != http.NoBody {
+			respBody, resp.Body = captureBody(resp.Body, t.config.bodyLimit)
+		}
+		_ = t.logger.log(DebugIssuer, noCaller, contextValues(req.Context(), []interface{}{target,
+			F("request_headers", t.headers(req.Header)),
+			F("request_body", string(reqBody)),
+			F("response_headers", t.headers(resp.Header)),
+			F("response_body", string(respBody))}))
+	}
+	_ = t.logger.log(statusLevel(resp.StatusCode), noCaller, contextValues(req.Context(), []interface{}{target,
+		F("status", resp.StatusCode),
+		F("bytes", resp.ContentLength),
+		F("duration", elapsed)}))
+	return resp, nil
+}
+
+// headers renders h as "Name: value; ..." sorted by name, masking redacted headers.
+func (t *loggingTransport) headers(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(name)
+		b.WriteString(": ")
+		if t.config.redactHeaders[http.CanonicalHeaderKey(name)] {
+			b.WriteString(redactedMask)
+			continue
+		}
+		b.WriteString(strings.Join(h[name], ", "))
+	}
+	return b.String()
+}
+
+// captureBody reads up to limit bytes from body and returns them together with a
+// replacement body that yields the full original content.
+func captureBody(body io.ReadCloser, limit int) ([]byte, io.ReadCloser) {
+	prefix, _ := io.ReadAll(io.LimitReader(body, int64(limit)))
+	return prefix, capturedBody{Reader: io.MultiReader(bytes.NewReader(prefix), body), Closer: body}
+}
@@ -1,6 +1,14 @@
 package loggy
 
-import "io"
+import (
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Severity defines the logging severity level as an unsigned 32-bit integer.
 // Lower values indicate higher priority messages.
@@ -10,12 +18,62 @@ type Severity uint32
 // the logger's identifier, output destination, severity filtering level, time format,
 // timezone configuration, and custom severity names.
 type Logger struct {
-	name          string    // Logger identifier in the format ": name:".
-	writer        io.Writer // Destination for log output (e.g., os.Stdout).
-	minLevel      Severity  // Minimum severity level to log; lower levels are ignored.
-	timeFormat    string    // Format for timestamps (Go reference time format).
-	useUTC        bool      // If true, log timestamps are in UTC; otherwise, local time.
-	severityNames []string  // Custom labels for each severity level.
+	name          string                        // Logger identifier in the format ": name:".
+	writer        io.Writer                     // Destination for log output (e.g., os.Stdout).
+	minLevel      atomic.Uint32                 // Minimum severity level to log; lower levels are ignored. inheritLevel defers to parent.
+	parent        *Logger                       // Logger this sub-logger was created from with Named; nil for root Loggers.
+	timeFormat    string                        // Format for timestamps (Go reference time format).
+	useUTC        bool                          // If true, log timestamps are in UTC; otherwise, local time.
+	severityNames []string                      // Custom labels for each severity level.
+	colorMode     ColorMode                     // Strategy used to decide whether output is colourised.
+	colorTheme    ColorTheme                    // ANSI SGR parameters applied to each severity label.
+	callerStyle   CallerStyle                   // How the caller location is rendered, or CallerNone to skip the lookup.
+	callerFunc    bool                          // If true, the caller's function name is appended to the location.
+	stackLevel    Severity                      // Minimum severity at which a stack trace is attached; DisableIssuer turns it off.
+	hooks         []hookBinding                 // Hooks invoked on every entry before it is encoded.
+	redactor      *Redactor                     // Redaction applied to messages and fields before hooks run; nil disables it.
+	panicLevel    Severity                      // Severity of recovered panics; FatalIssuer re-panics after logging.
+	counters      *loggerCounters               // Entry counters shared by all Loggers with the same name.
+	errorHandler  func(error)                   // Receives rate-limited reports of failed writes; nil reports to the fallback writer.
+	fallback      io.Writer                     // Receives entries the writer failed to write; nil disables the fallback.
+	errorInterval time.Duration                 // Minimum interval between two reports of failed writes.
+	failures      writeFailures                 // Rate-limiting state of write failure reports.
+	unlisted      bool                          // If true, the Logger is not added to the global registry.
+	closers       []io.Closer                   // Outputs opened by Config.Build, closed by Close.
+	helper        interface{ Helper() }         // Test helper marker (testing.TB) called on every frame of the logging path.
+	mu            sync.Mutex                    // Serialises reconfiguration of the layout at runtime.
+	current       atomic.Pointer[layout]        // Snapshot of the rendering settings read by Log; unused by sub-loggers.
+	overrides     layoutFields                  // Layout settings set by an Option, which a sub-logger overrides.
+	derived       atomic.Pointer[derivedLayout] // Sub-logger layout derived from its parent's; nil if it has no overrides.
+}
+
+// nopHelper is the test helper marker of Loggers not created by NewTestLogger.
+type nopHelper struct{}
+
+// layout is an immutable snapshot of the settings used to render entries. Options write
+// to the Logger's fields; the snapshot is then published atomically so that settings
+// can be changed at runtime without racing with concurrent Log calls.
+type layout struct {
+	writer        io.Writer
+	timeFormat    string
+	useUTC        bool
+	severityNames []string
+	colorMode     ColorMode
+	colorTheme    ColorTheme
+	colorize      bool
+	callerStyle   CallerStyle
+	callerFunc    bool
+}
+
+// layoutFields is a set of layout settings, used to record the settings a sub-logger
+// overrides.
+type layoutFields uint8
+
+// derivedLayout caches the layout of a sub-logger with overrides together with the
+// parent layout it was derived from, so that it is recomputed when the parent changes.
+type derivedLayout struct {
+	base *layout
+	ly   *layout
 }
 
 // Option defines a functional option for configuring a Logger instance during creation.
@@ -33,3 +91,323 @@ type locker interface {
 	Lock()
 	Unlock()
 }
+
+// ColorMode controls whether the Logger decorates its output with ANSI escape sequences.
+type ColorMode uint8
+
+// ColorTheme maps each severity level to the ANSI SGR parameters (e.g. "1;31" for bold red)
+// used to colour its label. Levels without an entry are written without colour.
+type ColorTheme map[Severity]string
+
+// CallerStyle determines how the source location of a log call is rendered.
+type CallerStyle uint8
+
+// Frame describes a single source location captured from the call stack.
+type Frame struct {
+	Function string // Fully qualified function name (e.g. "github.com/org/app/api.(*Server).Handle").
+	File     string // Absolute path of the source file as recorded by the compiler.
+	Line     int    // Line number within File.
+}
+
+// Entry is a single log record as seen by hooks before it is encoded and written.
+// Hooks may modify any of its fields; changes are reflected in the written output.
+type Entry struct {
+	Level   Severity  // Severity of the entry.
+	Time    time.Time // Timestamp of the entry, already converted to UTC if configured.
+	Logger  string    // Name of the logger that produced the entry, as returned by Name.
+	Caller  Frame     // Source location of the log call; zero if unavailable or disabled.
+	Message string    // Message composed from the non-field arguments.
+	Fields  []Field   // Structured key/value pairs attached to the entry.
+	Stack   []Frame   // Call stack, if configured with WithStackTrace.
+}
+
+// Field is a structured key/value pair attached to a log entry. Fields may be passed
+// anywhere among the message arguments of Log and the level methods; they are removed
+// from the message and written as key=value pairs after it.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Hook is invoked for every entry at one of its registered severities before the
+// entry is encoded. It may mutate the entry (e.g. to add fields) and returns false to
+// veto it, in which case nothing is written and later hooks are not run.
+type Hook interface {
+	Fire(e *Entry) bool
+}
+
+// HookFunc is an adapter that allows an ordinary function to be used as a Hook.
+type HookFunc func(e *Entry) bool
+
+// hookBinding associates a Hook with the set of severities it is invoked for.
+type hookBinding struct {
+	hook   Hook
+	levels uint32 // Bit set of severities; bit n corresponds to Severity(n).
+}
+
+// RedactMode determines how sensitive values are neutralised by a Redactor.
+type RedactMode uint8
+
+// RedactRule describes a pattern of sensitive data to be redacted from messages and field values.
+type RedactRule struct {
+	Name    string            // Human-readable identifier of the rule (e.g. "email").
+	Pattern *regexp.Regexp    // Pattern matching the sensitive data.
+	Verify  func(string) bool // Optional check applied to each match to reduce false positives.
+}
+
+// Redactor removes secrets and personal data from entries before they reach hooks or the writer.
+// Rules are applied to the message and to the values of all fields; fields whose key appears
+// in DenyKeys (case-insensitive) are redacted entirely, whatever their value.
+type Redactor struct {
+	Rules    []RedactRule // Patterns redacted from messages and field values.
+	DenyKeys []string     // Field keys whose values are always redacted (e.g. "password").
+	Mode     RedactMode   // How matches are neutralised; defaults to RedactMask.
+}
+
+// stdLogWriter is an io.Writer that turns output of the standard library's log package
+// into entries of a Logger at a fixed severity.
+type stdLogWriter struct {
+	logger *Logger
+	level  Severity
+}
+
+// lineWriter is an io.WriteCloser that splits written data into lines and logs each
+// line as a separate entry of a Logger at a fixed severity.
+type lineWriter struct {
+	mu     sync.Mutex
+	logger *Logger
+	level  Severity
+	buf    []byte // Partial line awaiting its terminating newline.
+	closed bool
+}
+
+// RotatingFile is an io.WriteCloser that appends to a file and rotates it once it
+// exceeds a maximum size, keeping a bounded number of numbered backups
+// (app.log.1 being the most recent). It is safe for concurrent use.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// Config is a declarative description of a Logger that can be loaded from JSON and
+// LOGGY_* environment variables. The zero value describes a logger equivalent to Default.
+type Config struct {
+	Name          string         `json:"name"`           // Plain logger name (e.g. "my-service"); defaults to the executable name.
+	Level         string         `json:"level"`          // Minimum severity: "debug", "info", "warn", "error", "fatal" or "disable".
+	TimeFormat    string         `json:"time_format"`    // Timestamp layout in Go reference time.
+	UTC           bool           `json:"utc"`            // Use UTC timestamps instead of local time.
+	SeverityNames []string       `json:"severity_names"` // Exactly five labels for Debug through Fatal.
+	Outputs       []string       `json:"outputs"`        // "stdout", "stderr" or file paths; defaults to stdout.
+	Encoder       string         `json:"encoder"`        // "text" (plain) or "console" (coloured when writing to a terminal).
+	Caller        string         `json:"caller"`         // Caller style: "base", "module", "package", "full" or "none".
+	Rotation      RotationConfig `json:"rotation"`       // Size-based rotation applied to file outputs.
+}
+
+// RotationConfig describes size-based rotation of file outputs.
+// Rotation is disabled when MaxSizeMB is zero.
+type RotationConfig struct {
+	MaxSizeMB  int `json:"max_size_mb"` // Size in megabytes at which a file is rotated.
+	MaxBackups int `json:"max_backups"` // Number of rotated files to keep; zero keeps none.
+}
+
+// ConfigError describes a single invalid setting found while validating a Config.
+type ConfigError struct {
+	Field  string // Name of the offending setting, as used in JSON (e.g. "level").
+	Reason string // Description of the problem.
+}
+
+// ConfigWatcher keeps live Loggers in sync with a configuration file. It reloads the file
+// when its modification time or size changes, when SIGHUP is received, or when Reload is
+// called, and applies the differences to the watched Loggers.
+type ConfigWatcher struct {
+	mu       sync.Mutex
+	path     string
+	loggers  []*Logger
+	config   Config        // Last successfully applied configuration.
+	modTime  time.Time     // Modification time of the file when it was last read.
+	size     int64         // Size of the file when it was last read.
+	closers  []io.Closer   // Outputs opened by the watcher for the current configuration.
+	out      *switchWriter // Writer installed on the Loggers by the first output change; nil before.
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// LevelHandler is an http.Handler that lists Loggers with their current level and changes
+// levels at runtime, optionally reverting them after a time-to-live.
+type LevelHandler struct {
+	mu          sync.Mutex
+	loggers     map[string]*Logger // Loggers given to NewLevelHandler or Register.
+	overrides   map[string]*levelOverride
+	useRegistry bool // Whether Loggers in the global registry are served too.
+}
+
+// levelOverride records a temporary level change made through a LevelHandler.
+type levelOverride struct {
+	previous  Severity    // Level to restore when the override expires.
+	inherited bool        // Whether the Logger inherited its level, in which case inheritance is restored instead.
+	expires   time.Time   // When the override expires.
+	timer     *time.Timer // Fires when the override expires.
+}
+
+// levelState is the JSON representation of a Logger's level used by LevelHandler.
+type levelState struct {
+	Name    string     `json:"name"`
+	Level   string     `json:"level"`
+	Expires *time.Time `json:"expires,omitempty"` // When a temporary level reverts, if any.
+}
+
+// AccessLogFormat selects the layout of entries written by HTTPMiddleware.
+type AccessLogFormat uint8
+
+// MiddlewareOption configures HTTPMiddleware.
+type MiddlewareOption func(*middlewareConfig)
+
+// middlewareConfig holds the settings of HTTPMiddleware.
+type middlewareConfig struct {
+	format          AccessLogFormat
+	requestIDHeader string
+}
+
+// responseRecorder wraps an http.ResponseWriter to capture the status code and body size.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// contextKey is the type of context keys defined by this package.
+type contextKey int
+
+// TransportOption configures NewTransport.
+type TransportOption func(*transportConfig)
+
+// transportConfig holds the settings of the logging RoundTripper.
+type transportConfig struct {
+	bodyLimit     int
+	redactHeaders map[string]bool
+}
+
+// loggingTransport is an http.RoundTripper that logs each request through a Logger.
+type loggingTransport struct {
+	logger *Logger
+	next   http.RoundTripper
+	config transportConfig
+}
+
+// capturedBody replays the captured prefix of a body followed by the unread remainder.
+type capturedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// contextField associates a context key with the name of the field it is logged as.
+type contextField struct {
+	name string
+	key  interface{}
+}
+
+// contextRegistry is a copy-on-write list of registered context fields, so that the
+// Ctx methods read it without locking.
+type contextRegistry struct {
+	mu     sync.Mutex
+	fields atomic.Pointer[[]contextField]
+}
+
+// TraceContext is a W3C Trace Context (https://www.w3.org/TR/trace-context/), as carried
+// by the traceparent and tracestate headers.
+type TraceContext struct {
+	TraceID [16]byte // identifies the whole trace; never all zeros in a valid context
+	SpanID  [8]byte  // identifies the current span (the parent-id of outgoing requests)
+	Flags   byte     // trace flags; bit 0 is the sampled flag
+	State   string   // vendor-specific tracestate list, propagated unchanged
+}
+
+// ErrorChain is the value of Fields created by Err. It renders the wrapped error with its
+// chain of causes, concrete types, fields and stack trace. A Redactor replaces it with a
+// chain whose messages and fields are redacted.
+type ErrorChain struct {
+	Err  error
+	info *ErrorInfo // Description of Err computed once per entry, then redacted by a Redactor; nil describes Err on demand.
+}
+
+// ErrorInfo describes an error and the errors it wraps, as produced by ErrorChain.Info.
+type ErrorInfo struct {
+	Type    string      // Concrete type, e.g. "*fs.PathError".
+	Message string      // Result of Error().
+	Fields  []Field     // Fields exposed through FieldError.
+	Stack   []Frame     // Stack exposed through StackTracer.
+	Causes  []ErrorInfo // Wrapped errors: one for Unwrap() error, any number for Unwrap() []error.
+}
+
+// StackTracer is implemented by errors that record the stack where they were created.
+type StackTracer interface {
+	StackTrace() []Frame
+}
+
+// FieldError is implemented by errors that carry structured context to be logged with them.
+type FieldError interface {
+	LogFields() []Field
+}
+
+// LevelStats holds the number of entries of one severity handled by a Logger name.
+type LevelStats struct {
+	Emitted     uint64 `json:"emitted"`      // Entries written successfully.
+	Filtered    uint64 `json:"filtered"`     // Entries below the minimum severity.
+	Dropped     uint64 `json:"dropped"`      // Entries vetoed by a hook.
+	WriteErrors uint64 `json:"write_errors"` // Entries whose write failed.
+}
+
+// levelCounters is the live, atomically updated counterpart of LevelStats.
+type levelCounters struct {
+	emitted     atomic.Uint64
+	filtered    atomic.Uint64
+	dropped     atomic.Uint64
+	writeErrors atomic.Uint64
+}
+
+// loggerCounters holds the counters of each severity from DebugIssuer to FatalIssuer.
+type loggerCounters [DisableIssuer]levelCounters
+
+// metricsRegistry maps Logger names to their counters.
+type metricsRegistry struct {
+	mu     sync.Mutex
+	byName map[string]*loggerCounters
+}
+
+// WriteError reports that a Logger failed to write entries to its writer.
+type WriteError struct {
+	Logger     string // Name of the Logger, as returned by Name.
+	Err        error  // Error returned by the writer.
+	Suppressed uint64 // Number of failures not reported since the previous report.
+}
+
+// writeFailures holds the rate-limiting state of write failure reports.
+type writeFailures struct {
+	last       atomic.Int64  // Time of the last report in Unix nanoseconds; zero if none.
+	suppressed atomic.Uint64 // Failures not reported since the last report.
+}
+
+// loggerRegistry is the global registry of Loggers by name, with the level rules set by SetLevels.
+type loggerRegistry struct {
+	mu      sync.Mutex
+	loggers map[string]*Logger
+	rules   []levelRule
+}
+
+// levelRule assigns a level to the Loggers whose names match a glob pattern.
+type levelRule struct {
+	pattern string
+	level   Severity
+}
+
+// switchWriter forwards writes to a writer that can be replaced while Loggers use it.
+// Replacing waits for in-flight writes, so that the previous writer may be closed safely.
+type switchWriter struct {
+	mu sync.RWMutex
+	w  io.Writer
+}
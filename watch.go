@@ -0,0 +1,276 @@
+package loggy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WatchConfig starts watching the JSON configuration file at path and applies changes to
+// the given Loggers while they keep running:
+//
+//   - level changes are applied with SetLevel;
+//   - time format, UTC, severity names, encoder and caller changes take effect atomically
+//     for subsequent entries;
+//   - output and rotation changes open the new outputs and switch to them with UpdateWriter.
+//
+// The file is polled every interval and also reloaded on SIGHUP. The configuration read
+// initially is taken as the baseline and is not applied, as the Loggers are expected to
+// have been built from it. Every reload is reported through the first Logger: a summary
+// of what changed, or the validation errors of a rejected configuration, in which case
+// the running Loggers are left untouched. Changing the name requires a restart.
+//
+// Example:
+//
+//	cfg, _ := LoadConfig("loggy.json")
+//	logger, _ := cfg.Build()
+//	w, err := WatchConfig("loggy.json", 5*time.Second, logger)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+func WatchConfig(path string, interval time.Duration, loggers ...*Logger) (*ConfigWatcher, error) {
+	if len(loggers) == 0 {
+		return nil, errors.New("loggy: no loggers to watch")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("loggy: invalid poll interval %v", interval)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	w := &ConfigWatcher{
+		path:    path,
+		loggers: loggers,
+		config:  cfg,
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run(interval)
+	return w, nil
+}
+
+// Config returns the configuration most recently applied.
+func (w *ConfigWatcher) Config() Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.config
+}
+
+// Reload reads the configuration file and applies it immediately, regardless of whether
+// it changed on disk. It returns the validation or I/O error if the file was rejected.
+func (w *ConfigWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if fi, err := os.Stat(w.path); err == nil {
+		w.modTime, w.size = fi.ModTime(), fi.Size()
+	}
+	return w.reload()
+}
+
+// Close stops watching. Outputs opened by the watcher stay open, as the Loggers may still
+// be writing to them.
+func (w *ConfigWatcher) Close() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+// run polls the file and listens for reload signals until Close is called.
+func (w *ConfigWatcher) run(interval time.Duration) {
+	defer close(w.done)
+	sig := make(chan os.Signal, 1)
+	if len(reloadSignals) > 0 {
+		signal.Notify(sig, reloadSignals...)
+		defer signal.Stop(sig)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-sig:
+			_ = w.Reload()
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll reloads the file if its modification time or size changed since it was last read.
+func (w *ConfigWatcher) poll() {
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if fi.ModTime().Equal(w.modTime) && fi.Size() == w.size {
+		return
+	}
+	w.modTime, w.size = fi.ModTime(), fi.Size()
+	_ = w.reload()
+}
+
+// reload loads, validates and applies the configuration file. w.mu must be held.
+func (w *ConfigWatcher) reload() error {
+	report := w.loggers[0]
+	cfg, err := LoadConfig(w.path)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		_ = report.Log(ErrorIssuer, "config rejected: ", strings.ReplaceAll(err.Error(), "\n", "; "))
+		return err
+	}
+	changes := diffConfig(w.config, cfg)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Open new outputs first so that a failure leaves the running Loggers untouched.
+	outputsChanged := !slices.Equal(cfg.Outputs, w.config.Outputs) || cfg.Rotation != w.config.Rotation
+	var (
+		out     io.Writer
+		closers []io.Closer
+	)
+	if outputsChanged {
+		if out, closers, err = openOutputs(cfg.Outputs, cfg.Rotation); err != nil {
+			_ = report.Log(ErrorIssuer, "config rejected: ", err.Error())
+			return err
+		}
+	}
+
+	if cfg.Level != w.config.Level {
+		for _, l := range w.loggers {
+			l.SetLevel(cfg.level())
+		}
+	}
+	if cfg.TimeFormat != w.config.TimeFormat || cfg.UTC != w.config.UTC ||
+		!slices.Equal(cfg.SeverityNames, w.config.SeverityNames) ||
+		cfg.Encoder != w.config.Encoder || cfg.Caller != w.config.Caller {
+		opts := cfg.layoutOptions()
+		for _, l := range w.loggers {
+			l.reconfigure(opts...)
+		}
+	}
+	if outputsChanged {
+		// The Loggers are switched once to a writer owned by the watcher; later changes
+		// replace its target, which waits for in-flight writes before the old outputs close.
+		if w.out == nil {
+			w.out = &switchWriter{w: out}
+			for _, l := range w.loggers {
+				if !l.UpdateWriter(w.out) {
+					_ = report.Log(WarnIssuer, "config: outputs not applied to logger ", l.Name(), ": incompatible writer")
+				}
+			}
+		} else {
+			w.out.swap(out)
+			// Re-evaluate terminal detection for the new target.
+			for _, l := range w.loggers {
+				l.reconfigure()
+			}
+		}
+		for _, c := range w.closers {
+			c.Close()
+		}
+		w.closers = closers
+	}
+	w.config = cfg
+	_ = report.Log(InfoIssuer, "config reloaded: ", strings.Join(changes, "; "))
+	return nil
+}
+
+// layoutOptions returns Options that set every rendering setting, restoring defaults for
+// those left empty, so that a setting removed from the file is reverted on reload.
+func (c Config) layoutOptions() []Option {
+	timeFormat := c.TimeFormat
+	if timeFormat == "" {
+		timeFormat = defaultTimeFormat
+	}
+	names := c.SeverityNames
+	if names == nil {
+		names = defaultSeverityNames()
+	}
+	color := ColorNever
+	if strings.EqualFold(c.Encoder, "console") {
+		color = ColorAuto
+	}
+	return []Option{
+		WithTimeFormat(timeFormat),
+		WithUTC(c.UTC),
+		WithSeverityNames(names),
+		WithColor(color),
+		WithCallerStyle(callerStyleNames[strings.ToLower(c.Caller)]),
+	}
+}
+
+// diffConfig describes each setting that differs between prev and next as "key: old -> new".
+func diffConfig(prev, next Config) []string {
+	var changes []string
+	add := func(key string, a, b interface{}) {
+		changes = append(changes, fmt.Sprintf("%s: %v -> %v", key, a, b))
+	}
+	if prev.Name != next.Name {
+		changes = append(changes, fmt.Sprintf("name: %q -> %q (requires restart)", prev.Name, next.Name))
+	}
+	if prev.Level != next.Level {
+		add("level", prev.level(), next.level())
+	}
+	if prev.TimeFormat != next.TimeFormat {
+		add("time_format", fmt.Sprintf("%q", prev.TimeFormat), fmt.Sprintf("%q", next.TimeFormat))
+	}
+	if prev.UTC != next.UTC {
+		add("utc", prev.UTC, next.UTC)
+	}
+	if !slices.Equal(prev.SeverityNames, next.SeverityNames) {
+		add("severity_names", fmt.Sprintf("%q", prev.SeverityNames), fmt.Sprintf("%q", next.SeverityNames))
+	}
+	if !slices.Equal(prev.Outputs, next.Outputs) {
+		add("outputs", prev.Outputs, next.Outputs)
+	}
+	if prev.Encoder != next.Encoder {
+		add("encoder", fmt.Sprintf("%q", prev.Encoder), fmt.Sprintf("%q", next.Encoder))
+	}
+	if prev.Caller != next.Caller {
+		add("caller", fmt.Sprintf("%q", prev.Caller), fmt.Sprintf("%q", next.Caller))
+	}
+	if prev.Rotation != next.Rotation {
+		add("rotation", fmt.Sprintf("%+v", prev.Rotation), fmt.Sprintf("%+v", next.Rotation))
+	}
+	return changes
+}
+
+// Write forwards p to the current target, holding its lock if it implements locker.
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if lock, ok := s.w.(locker); ok {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+	return s.w.Write(p)
+}
+
+// swap replaces the target once no write is in progress.
+func (s *switchWriter) swap(w io.Writer) {
+	s.mu.Lock()
+	s.w = w
+	s.mu.Unlock()
+}
@@ -0,0 +1,222 @@
+package loggy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, implementing the locker interface.
+type syncBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) { return b.buf.Write(p) }
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
+
+// writeConfig writes a configuration file, ensuring its modification time changes.
+func writeConfig(t *testing.T, path, data string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestConfigWatcherReload verifies that changes are applied and reported.
+func TestConfigWatcherReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "loggy.json")
+	t.Setenv("LOGGY_LEVEL", "")
+	os.Unsetenv("LOGGY_LEVEL")
+	writeConfig(t, path, `{"name": "svc", "level": "info"}`, time.Now().Add(-time.Hour))
+
+	buf := &syncBuffer{}
+	logger := New(": svc:", buf, InfoIssuer)
+	w, err := WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error from WatchConfig: %v", err)
+	}
+	defer w.Close()
+
+	out := filepath.Join(dir, "app.log")
+	writeConfig(t, path, `{"name": "svc", "level": "debug", "time_format": "15:04", "outputs": ["`+filepath.ToSlash(out)+`"]}`, time.Now())
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Unexpected error from Reload: %v", err)
+	}
+	if logger.GetLevel() != DebugIssuer {
+		t.Errorf("Expected level to be debug, got %v", logger.GetLevel())
+	}
+	_ = logger.Debug("after reload")
+	data, _ := os.ReadFile(out)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "config reloaded: level: info -> debug") ||
+		!strings.Contains(lines[0], `time_format: "" -> "15:04"`) || !strings.Contains(lines[1], "after reload") {
+		t.Fatalf("Unexpected output after reload: %q", data)
+	}
+	if len(lines[1]) < 5 || lines[1][2] != ':' || lines[1][5] != ':' {
+		t.Errorf("Expected the new time format to be used, got: %q", lines[1])
+	}
+}
+
+// TestConfigWatcherRejectsInvalid verifies that an invalid configuration leaves the logger untouched.
+func TestConfigWatcherRejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loggy.json")
+	writeConfig(t, path, `{"level": "warn"}`, time.Now().Add(-time.Hour))
+	buf := &syncBuffer{}
+	logger := New(": svc:", buf, WarnIssuer)
+	w, err := WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error from WatchConfig: %v", err)
+	}
+	defer w.Close()
+
+	writeConfig(t, path, `{"level": "loud", "caller": "short"}`, time.Now())
+	if err := w.Reload(); err == nil {
+		t.Fatal("Expected Reload to reject an invalid configuration")
+	}
+	if logger.GetLevel() != WarnIssuer || w.Config().Level != "warn" {
+		t.Errorf("Expected the running configuration to be kept, got level %v", logger.GetLevel())
+	}
+	output := buf.String()
+	if !strings.Contains(output, "error: ") || !strings.Contains(output, "config rejected: ") ||
+		!strings.Contains(output, "level") || !strings.Contains(output, "caller") {
+		t.Errorf("Expected the rejection to be reported with all errors, got: %s", output)
+	}
+}
+
+// TestConfigWatcherPolling verifies that modifications are picked up by polling.
+func TestConfigWatcherPolling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loggy.json")
+	writeConfig(t, path, `{"level": "info"}`, time.Now().Add(-time.Hour))
+	logger := New(": svc:", &syncBuffer{}, InfoIssuer)
+	w, err := WatchConfig(path, 5*time.Millisecond, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error from WatchConfig: %v", err)
+	}
+	defer w.Close()
+
+	writeConfig(t, path, `{"level": "error"}`, time.Now())
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.GetLevel() != ErrorIssuer {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the level change to be applied, level is %v", logger.GetLevel())
+		}
+		_ = logger.Info("polling")
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestConfigWatcherReachesSubLoggers verifies that reloaded outputs and layout settings
+// apply to sub-loggers of the watched Logger.
+func TestConfigWatcherReachesSubLoggers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "loggy.json")
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	writeConfig(t, path, `{"name": "svc", "level": "info", "outputs": ["`+filepath.ToSlash(first)+`"]}`, time.Now().Add(-time.Hour))
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, err := cfg.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error from Build: %v", err)
+	}
+	defer logger.Close()
+	db := logger.Named("db")
+	w, err := WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error from WatchConfig: %v", err)
+	}
+	defer w.Close()
+
+	writeConfig(t, path, `{"name": "svc", "level": "info", "outputs": ["`+filepath.ToSlash(second)+`"], "severity_names": ["D ", "I ", "W ", "E ", "F "]}`, time.Now())
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Unexpected error from Reload: %v", err)
+	}
+	if err := db.Info("reloaded"); err != nil {
+		t.Errorf("Unexpected error from Info: %v", err)
+	}
+	data, _ := os.ReadFile(second)
+	if !strings.Contains(string(data), ": svc.db:I ") || !strings.HasSuffix(string(data), ": reloaded\n") {
+		t.Errorf("Expected the sub-logger to use the reloaded output and settings, got: %q", data)
+	}
+	if data, _ := os.ReadFile(first); strings.Contains(string(data), "reloaded") {
+		t.Errorf("Expected nothing written to the replaced output, got: %q", data)
+	}
+}
+
+// TestConfigWatcherReloadWhileLogging verifies that outputs can be switched while other
+// goroutines are logging: run with -race, no entry may be lost or hit a closed file.
+func TestConfigWatcherReloadWhileLogging(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "loggy.json")
+	t.Setenv("LOGGY_OUTPUTS", "")
+	os.Unsetenv("LOGGY_OUTPUTS")
+	outputs := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log"), filepath.Join(dir, "c.log")}
+	config := func(out string) string {
+		return `{"name": "svc", "level": "info", "outputs": ["` + filepath.ToSlash(out) + `"]}`
+	}
+	writeConfig(t, path, config(outputs[0]), time.Now().Add(-time.Hour))
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, err := cfg.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error from Build: %v", err)
+	}
+	defer logger.Close()
+	w, err := WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error from WatchConfig: %v", err)
+	}
+	defer w.Close()
+
+	const workers, perWorker = 4, 300
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				if err := logger.Info("tick"); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for i := 1; i <= 6; i++ {
+		writeConfig(t, path, config(outputs[i%len(outputs)]), time.Now().Add(time.Duration(i)*time.Second))
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected error from Reload: %v", err)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Unexpected write error while reloading: %v", err)
+	}
+
+	total := 0
+	for _, out := range outputs {
+		data, _ := os.ReadFile(out)
+		total += strings.Count(string(data), ": tick\n")
+	}
+	if total != workers*perWorker {
+		t.Errorf("Expected %d entries across the outputs, got %d", workers*perWorker, total)
+	}
+}
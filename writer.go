@@ -0,0 +1,104 @@
+package loggy
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// Writer returns an io.WriteCloser that logs every line written to it as a separate entry
+// at the given severity. Partial writes are buffered until a newline arrives, carriage
+// returns before the newline are removed, and lines longer than 64 KiB are split into
+// several entries so that memory use stays bounded. Close flushes any trailing partial
+// line. The returned writer is safe for concurrent use.
+//
+// Example:
+//
+//	stderr := logger.Writer(WarnIssuer)
+//	defer stderr.Close()
+//	cmd := exec.Command("make", "build")
+//	cmd.Stderr = stderr
+func (l *Logger) Writer(level Severity) io.WriteCloser {
+	return &lineWriter{logger: l, level: level}
+}
+
+// Write buffers p and logs each complete line it contains.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			w.flushLong()
+			break
+		}
+		var line []byte
+		if len(w.buf) > 0 {
+			w.buf = append(w.buf, p[:i]...)
+			line, w.buf = w.buf, w.buf[:0]
+		} else {
+			line = p[:i]
+		}
+		p = p[i+1:]
+		w.emitLong(line)
+	}
+	return n, nil
+}
+
+// Close logs the trailing partial line, if any. Subsequent writes fail with ErrWriterClosed.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if len(w.buf) > 0 {
+		w.emit(w.buf, 1)
+		w.buf = nil
+	}
+	return nil
+}
+
+// flushLong logs leading chunks of the pending partial line while it exceeds maxLineLength.
+func (w *lineWriter) flushLong() {
+	for len(w.buf) > maxLineLength {
+		cut := chunkEnd(w.buf)
+		w.emit(w.buf[:cut], 2)
+		w.buf = append(w.buf[:0], w.buf[cut:]...)
+	}
+}
+
+// emitLong logs a complete line, splitting it into chunks of at most maxLineLength bytes.
+func (w *lineWriter) emitLong(line []byte) {
+	for len(line) > maxLineLength {
+		cut := chunkEnd(line)
+		w.emit(line[:cut], 2)
+		line = line[cut:]
+	}
+	w.emit(line, 2)
+}
+
+// emit logs a single line. skip is the number of frames between emit and the
+// writer method (Write or Close) whose caller is reported as the source location.
+func (w *lineWriter) emit(line []byte, skip Caller) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	_ = w.logger.Log(w.level, skip, string(line))
+}
+
+// chunkEnd returns the length of the first chunk of b to log, at most maxLineLength bytes
+// and, where possible, ending on a UTF-8 rune boundary.
+func chunkEnd(b []byte) int {
+	cut := maxLineLength
+	for i := cut; i > cut-utf8.UTFMax && i > 0; i-- {
+		if utf8.RuneStart(b[i]) {
+			return i
+		}
+	}
+	return cut
+}
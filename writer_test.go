@@ -0,0 +1,76 @@
+package loggy
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestWriterSplitsLines verifies that partial writes are buffered and split on newlines.
+func TestWriterSplitsLines(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerStyle(CallerNone))
+	w := logger.Writer(WarnIssuer)
+	_, _ = w.Write([]byte("first li"))
+	if buf.Len() != 0 {
+		t.Fatalf("Expected partial line to be buffered, got: %s", buf.String())
+	}
+	_, _ = w.Write([]byte("ne\r\nsecond line\nthird"))
+	if err := w.Close(); err != nil {
+		t.Errorf("Unexpected error from Close: %v", err)
+	}
+	want := []string{"warn: first line", "warn: second line", "warn: third"}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d entries, got %d: %q", len(want), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, want[i]) {
+			t.Errorf("Entry %d: expected suffix %q, got %q", i, want[i], line)
+		}
+	}
+	if _, err := w.Write([]byte("late\n")); err != ErrWriterClosed {
+		t.Errorf("Expected ErrWriterClosed after Close, got: %v", err)
+	}
+}
+
+// TestWriterLongLines verifies that long lines are split into bounded entries.
+func TestWriterLongLines(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer, WithCallerStyle(CallerNone))
+	w := logger.Writer(InfoIssuer).(*lineWriter)
+	long := strings.Repeat("x", 2*maxLineLength+10)
+	for i := 0; i < len(long); i += 1000 {
+		_, _ = w.Write([]byte(long[i:min(i+1000, len(long))]))
+		if len(w.buf) > maxLineLength {
+			t.Fatalf("Expected buffered data to stay bounded, got %d bytes", len(w.buf))
+		}
+	}
+	_ = w.Close()
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Errorf("Expected 3 entries for a line of %d bytes, got %d", len(long), n)
+	}
+	if strings.Count(buf.String(), "x") != len(long) {
+		t.Error("Expected no data to be lost when splitting")
+	}
+}
+
+// TestWriterCaller verifies that the caller of Write is reported.
+func TestWriterCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := New(": test-service:", buf, DebugIssuer)
+	w := logger.Writer(InfoIssuer)
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Fprintln(w, "via fmt")
+	_, _ = w.Write([]byte("direct\n"))
+	output := buf.String()
+	if !strings.Contains(output, "print.go:") {
+		t.Errorf("Expected fmt.Fprintln to be reported as the writer's caller, got: %s", output)
+	}
+	if want := "writer_test.go:" + strconv.Itoa(line+2) + ": direct"; !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got: %s", want, output)
+	}
+}